@@ -0,0 +1,112 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// ActionDiscover is the action query parameter value of discover
+	// requests.
+	ActionDiscover = "discover"
+)
+
+// DiscoverResponse is the response of a bridge application to the discover
+// action.
+type DiscoverResponse struct {
+	// Workflows defined by the bridge application.
+	Workflows []Workflow `json:"workflows"`
+}
+
+// Workflow is a workflow defined by a bridge application.
+type Workflow struct {
+	// Workflow identifier, which is unique within the environment.
+	WorkflowID string `json:"workflowId"`
+
+	// Name of the workflow, by default its workflow identifier.
+	Name string `json:"name,omitempty"`
+
+	// Description of the workflow.
+	Description *string `json:"description,omitempty"`
+
+	// Tags of the workflow.
+	Tags []string `json:"tags,omitempty"`
+
+	// JSON schema of the trigger payload.
+	Payload Schema `json:"payload"`
+
+	// Steps of the workflow, in execution order.
+	Steps []Step `json:"steps"`
+}
+
+// Step is a step of a workflow defined by a bridge application.
+type Step struct {
+	// Step identifier, which is unique within the workflow.
+	StepID string `json:"stepId"`
+
+	// Step type, such as email or delay.
+	Type string `json:"type"`
+
+	// JSON schema of the step controls.
+	Controls Schema `json:"controls"`
+}
+
+// Schema wraps the JSON schema of workflow payloads and step controls.
+type Schema struct {
+	// JSON schema.
+	Schema map[string]any `json:"schema,omitempty"`
+}
+
+// Discover requests the workflows of the bridge application at bridgeURL via
+// GET <bridgeURL>?action=discover. Responses outside the 2xx range fail.
+func Discover(ctx context.Context, client *http.Client, bridgeURL string) (*DiscoverResponse, error) {
+	u, err := url.Parse(bridgeURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid bridge URL (%s): %w", bridgeURL, err)
+	}
+
+	query := u.Query()
+	query.Set("action", ActionDiscover)
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("error discovering bridge workflows: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading bridge discover response: %w", err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("error discovering bridge workflows: unexpected response status %d", res.StatusCode)
+	}
+
+	var result DiscoverResponse
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding bridge discover response: %w", err)
+	}
+
+	return &result, nil
+}
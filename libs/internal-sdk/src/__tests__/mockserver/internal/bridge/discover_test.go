@@ -0,0 +1,55 @@
+package bridge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	var query string
+
+	bridgeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query = req.URL.RawQuery
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"workflows":[{"workflowId":"welcome","payload":{"schema":{"type":"object"}},"steps":[{"stepId":"send-email","type":"email","controls":{"schema":{"type":"object"}},"outputs":{}}]}],"bridge":{}}`))
+	}))
+	t.Cleanup(bridgeServer.Close)
+
+	got, err := Discover(context.Background(), bridgeServer.Client(), bridgeServer.URL+"/api/novu?token=abc")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if query != "action=discover&token=abc" {
+		t.Errorf("expected action and existing query parameters, got: %s", query)
+	}
+
+	if len(got.Workflows) != 1 || got.Workflows[0].WorkflowID != "welcome" {
+		t.Fatalf("expected welcome workflow, got: %+v", got.Workflows)
+	}
+
+	if steps := got.Workflows[0].Steps; len(steps) != 1 || steps[0].StepID != "send-email" || steps[0].Type != "email" || steps[0].Controls.Schema["type"] != "object" {
+		t.Errorf("expected send-email step with control schema, got: %+v", steps)
+	}
+}
+
+func TestDiscoverStatus(t *testing.T) {
+	t.Parallel()
+
+	bridgeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(bridgeServer.Close)
+
+	_, err := Discover(context.Background(), bridgeServer.Client(), bridgeServer.URL)
+
+	if err == nil {
+		t.Fatal("expected error for 503 response")
+	}
+}
@@ -0,0 +1,3 @@
+// Package bridge contains the client of bridge applications, which serve the
+// code-first workflows of an environment via the discover action.
+package bridge
@@ -0,0 +1,3 @@
+// Package catalog lists the OAS operations of the API and matches requests
+// against them.
+package catalog
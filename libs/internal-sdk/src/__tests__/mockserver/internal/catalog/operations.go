@@ -0,0 +1,571 @@
+package catalog
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"mockserver/internal/sdk/models/operations"
+
+	"github.com/gorilla/mux"
+)
+
+// Operation is an OAS operation of the API.
+type Operation struct {
+	// OAS operation identifier, as logged by the HTTP file directory.
+	ID string
+
+	// HTTP method, such as GET.
+	Method string
+
+	// URL path template, such as /v2/topics/{topicKey}.
+	Path string
+
+	// Request and response types of the operations package.
+	Request  reflect.Type
+	Response reflect.Type
+
+	// Documented response status codes.
+	StatusCodes []int
+}
+
+// OperationID returns the identifier of the API operation matching the method
+// and path of the request.
+func OperationID(req *http.Request) (string, bool) {
+	var match mux.RouteMatch
+
+	if !operationRouter.Match(req, &match) {
+		return "", false
+	}
+
+	return match.Route.GetName(), true
+}
+
+// operationRouter matches requests to Operations, named by identifier.
+var operationRouter = newOperationRouter()
+
+// RoutingOrder returns Operations in the order their routes must be
+// registered with a router. Paths with fewer variables come first, so that
+// /v1/subscribers/bulk takes precedence over /v1/subscribers/{subscriberId}.
+func RoutingOrder() []Operation {
+	result := slices.Clone(Operations)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.Count(result[i].Path, "{") < strings.Count(result[j].Path, "{")
+	})
+
+	return result
+}
+
+// newOperationRouter returns a router of Operations in RoutingOrder.
+func newOperationRouter() *mux.Router {
+	result := mux.NewRouter()
+
+	for _, op := range RoutingOrder() {
+		result.Methods(op.Method).Path(op.Path).Name(op.ID)
+	}
+
+	return result
+}
+
+// Operations lists every OAS operation of the API, sorted by identifier.
+var Operations = []Operation{
+	{
+		ID:          "EnvironmentsControllerV1_createEnvironment",
+		Method:      http.MethodPost,
+		Path:        "/v1/environments",
+		Request:     reflect.TypeFor[operations.EnvironmentsControllerV1CreateEnvironmentRequest](),
+		Response:    reflect.TypeFor[operations.EnvironmentsControllerV1CreateEnvironmentResponse](),
+		StatusCodes: []int{201, 400, 401, 402, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EnvironmentsControllerV1_deleteEnvironment",
+		Method:      http.MethodDelete,
+		Path:        "/v1/environments/{environmentId}",
+		Request:     reflect.TypeFor[operations.EnvironmentsControllerV1DeleteEnvironmentRequest](),
+		Response:    reflect.TypeFor[operations.EnvironmentsControllerV1DeleteEnvironmentResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EnvironmentsControllerV1_listMyEnvironments",
+		Method:      http.MethodGet,
+		Path:        "/v1/environments",
+		Request:     reflect.TypeFor[operations.EnvironmentsControllerV1ListMyEnvironmentsRequest](),
+		Response:    reflect.TypeFor[operations.EnvironmentsControllerV1ListMyEnvironmentsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EnvironmentsControllerV1_updateMyEnvironment",
+		Method:      http.MethodPut,
+		Path:        "/v1/environments/{environmentId}",
+		Request:     reflect.TypeFor[operations.EnvironmentsControllerV1UpdateMyEnvironmentRequest](),
+		Response:    reflect.TypeFor[operations.EnvironmentsControllerV1UpdateMyEnvironmentResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EventsController_broadcastEventToAll",
+		Method:      http.MethodPost,
+		Path:        "/v1/events/trigger/broadcast",
+		Request:     reflect.TypeFor[operations.EventsControllerBroadcastEventToAllRequest](),
+		Response:    reflect.TypeFor[operations.EventsControllerBroadcastEventToAllResponse](),
+		StatusCodes: []int{200, 201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EventsController_cancel",
+		Method:      http.MethodDelete,
+		Path:        "/v1/events/trigger/{transactionId}",
+		Request:     reflect.TypeFor[operations.EventsControllerCancelRequest](),
+		Response:    reflect.TypeFor[operations.EventsControllerCancelResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EventsController_trigger",
+		Method:      http.MethodPost,
+		Path:        "/v1/events/trigger",
+		Request:     reflect.TypeFor[operations.EventsControllerTriggerRequest](),
+		Response:    reflect.TypeFor[operations.EventsControllerTriggerResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "EventsController_triggerBulk",
+		Method:      http.MethodPost,
+		Path:        "/v1/events/trigger/bulk",
+		Request:     reflect.TypeFor[operations.EventsControllerTriggerBulkRequest](),
+		Response:    reflect.TypeFor[operations.EventsControllerTriggerBulkResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "IntegrationsController_createIntegration",
+		Method:      http.MethodPost,
+		Path:        "/v1/integrations",
+		Request:     reflect.TypeFor[operations.IntegrationsControllerCreateIntegrationRequest](),
+		Response:    reflect.TypeFor[operations.IntegrationsControllerCreateIntegrationResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "IntegrationsController_getActiveIntegrations",
+		Method:      http.MethodGet,
+		Path:        "/v1/integrations/active",
+		Request:     reflect.TypeFor[operations.IntegrationsControllerGetActiveIntegrationsRequest](),
+		Response:    reflect.TypeFor[operations.IntegrationsControllerGetActiveIntegrationsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "IntegrationsController_listIntegrations",
+		Method:      http.MethodGet,
+		Path:        "/v1/integrations",
+		Request:     reflect.TypeFor[operations.IntegrationsControllerListIntegrationsRequest](),
+		Response:    reflect.TypeFor[operations.IntegrationsControllerListIntegrationsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "IntegrationsController_removeIntegration",
+		Method:      http.MethodDelete,
+		Path:        "/v1/integrations/{integrationId}",
+		Request:     reflect.TypeFor[operations.IntegrationsControllerRemoveIntegrationRequest](),
+		Response:    reflect.TypeFor[operations.IntegrationsControllerRemoveIntegrationResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "IntegrationsController_setIntegrationAsPrimary",
+		Method:      http.MethodPost,
+		Path:        "/v1/integrations/{integrationId}/set-primary",
+		Request:     reflect.TypeFor[operations.IntegrationsControllerSetIntegrationAsPrimaryRequest](),
+		Response:    reflect.TypeFor[operations.IntegrationsControllerSetIntegrationAsPrimaryResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "IntegrationsController_updateIntegrationById",
+		Method:      http.MethodPut,
+		Path:        "/v1/integrations/{integrationId}",
+		Request:     reflect.TypeFor[operations.IntegrationsControllerUpdateIntegrationByIDRequest](),
+		Response:    reflect.TypeFor[operations.IntegrationsControllerUpdateIntegrationByIDResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "LayoutsController_create",
+		Method:      http.MethodPost,
+		Path:        "/v2/layouts",
+		Request:     reflect.TypeFor[operations.LayoutsControllerCreateRequest](),
+		Response:    reflect.TypeFor[operations.LayoutsControllerCreateResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "LayoutsController_delete",
+		Method:      http.MethodDelete,
+		Path:        "/v2/layouts/{layoutId}",
+		Request:     reflect.TypeFor[operations.LayoutsControllerDeleteRequest](),
+		Response:    reflect.TypeFor[operations.LayoutsControllerDeleteResponse](),
+		StatusCodes: []int{204, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "LayoutsController_duplicate",
+		Method:      http.MethodPost,
+		Path:        "/v2/layouts/{layoutId}/duplicate",
+		Request:     reflect.TypeFor[operations.LayoutsControllerDuplicateRequest](),
+		Response:    reflect.TypeFor[operations.LayoutsControllerDuplicateResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "LayoutsController_get",
+		Method:      http.MethodGet,
+		Path:        "/v2/layouts/{layoutId}",
+		Request:     reflect.TypeFor[operations.LayoutsControllerGetRequest](),
+		Response:    reflect.TypeFor[operations.LayoutsControllerGetResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "LayoutsController_list",
+		Method:      http.MethodGet,
+		Path:        "/v2/layouts",
+		Request:     reflect.TypeFor[operations.LayoutsControllerListRequest](),
+		Response:    reflect.TypeFor[operations.LayoutsControllerListResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "LayoutsController_update",
+		Method:      http.MethodPut,
+		Path:        "/v2/layouts/{layoutId}",
+		Request:     reflect.TypeFor[operations.LayoutsControllerUpdateRequest](),
+		Response:    reflect.TypeFor[operations.LayoutsControllerUpdateResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "MessagesController_deleteMessage",
+		Method:      http.MethodDelete,
+		Path:        "/v1/messages/{messageId}",
+		Request:     reflect.TypeFor[operations.MessagesControllerDeleteMessageRequest](),
+		Response:    reflect.TypeFor[operations.MessagesControllerDeleteMessageResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "MessagesController_deleteMessagesByTransactionId",
+		Method:      http.MethodDelete,
+		Path:        "/v1/messages/transaction/{transactionId}",
+		Request:     reflect.TypeFor[operations.MessagesControllerDeleteMessagesByTransactionIDRequest](),
+		Response:    reflect.TypeFor[operations.MessagesControllerDeleteMessagesByTransactionIDResponse](),
+		StatusCodes: []int{204, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "MessagesController_getMessages",
+		Method:      http.MethodGet,
+		Path:        "/v1/messages",
+		Request:     reflect.TypeFor[operations.MessagesControllerGetMessagesRequest](),
+		Response:    reflect.TypeFor[operations.MessagesControllerGetMessagesResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "NotificationsController_getNotification",
+		Method:      http.MethodGet,
+		Path:        "/v1/notifications/{notificationId}",
+		Request:     reflect.TypeFor[operations.NotificationsControllerGetNotificationRequest](),
+		Response:    reflect.TypeFor[operations.NotificationsControllerGetNotificationResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "NotificationsController_listNotifications",
+		Method:      http.MethodGet,
+		Path:        "/v1/notifications",
+		Request:     reflect.TypeFor[operations.NotificationsControllerListNotificationsRequest](),
+		Response:    reflect.TypeFor[operations.NotificationsControllerListNotificationsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_createSubscriber",
+		Method:      http.MethodPost,
+		Path:        "/v2/subscribers",
+		Request:     reflect.TypeFor[operations.SubscribersControllerCreateSubscriberRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerCreateSubscriberResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_getSubscriber",
+		Method:      http.MethodGet,
+		Path:        "/v2/subscribers/{subscriberId}",
+		Request:     reflect.TypeFor[operations.SubscribersControllerGetSubscriberRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerGetSubscriberResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_getSubscriberPreferences",
+		Method:      http.MethodGet,
+		Path:        "/v2/subscribers/{subscriberId}/preferences",
+		Request:     reflect.TypeFor[operations.SubscribersControllerGetSubscriberPreferencesRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerGetSubscriberPreferencesResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_listSubscriberTopics",
+		Method:      http.MethodGet,
+		Path:        "/v2/subscribers/{subscriberId}/subscriptions",
+		Request:     reflect.TypeFor[operations.SubscribersControllerListSubscriberTopicsRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerListSubscriberTopicsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_patchSubscriber",
+		Method:      http.MethodPatch,
+		Path:        "/v2/subscribers/{subscriberId}",
+		Request:     reflect.TypeFor[operations.SubscribersControllerPatchSubscriberRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerPatchSubscriberResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_removeSubscriber",
+		Method:      http.MethodDelete,
+		Path:        "/v2/subscribers/{subscriberId}",
+		Request:     reflect.TypeFor[operations.SubscribersControllerRemoveSubscriberRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerRemoveSubscriberResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_searchSubscribers",
+		Method:      http.MethodGet,
+		Path:        "/v2/subscribers",
+		Request:     reflect.TypeFor[operations.SubscribersControllerSearchSubscribersRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerSearchSubscribersResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersController_updateSubscriberPreferences",
+		Method:      http.MethodPatch,
+		Path:        "/v2/subscribers/{subscriberId}/preferences",
+		Request:     reflect.TypeFor[operations.SubscribersControllerUpdateSubscriberPreferencesRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersControllerUpdateSubscriberPreferencesResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_bulkCreateSubscribers",
+		Method:      http.MethodPost,
+		Path:        "/v1/subscribers/bulk",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerBulkCreateSubscribersRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerBulkCreateSubscribersResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_deleteSubscriberCredentials",
+		Method:      http.MethodDelete,
+		Path:        "/v1/subscribers/{subscriberId}/credentials/{providerId}",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerDeleteSubscriberCredentialsRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerDeleteSubscriberCredentialsResponse](),
+		StatusCodes: []int{204, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_getNotificationsFeed",
+		Method:      http.MethodGet,
+		Path:        "/v1/subscribers/{subscriberId}/notifications/feed",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerGetNotificationsFeedRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerGetNotificationsFeedResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_getUnseenCount",
+		Method:      http.MethodGet,
+		Path:        "/v1/subscribers/{subscriberId}/notifications/unseen",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerGetUnseenCountRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerGetUnseenCountResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_markActionAsSeen",
+		Method:      http.MethodPost,
+		Path:        "/v1/subscribers/{subscriberId}/messages/{messageId}/actions/{type}",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerMarkActionAsSeenRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerMarkActionAsSeenResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_markAllUnreadAsRead",
+		Method:      http.MethodPost,
+		Path:        "/v1/subscribers/{subscriberId}/messages/mark-all",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerMarkAllUnreadAsReadRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerMarkAllUnreadAsReadResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_markMessagesAs",
+		Method:      http.MethodPost,
+		Path:        "/v1/subscribers/{subscriberId}/messages/mark-as",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerMarkMessagesAsRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerMarkMessagesAsResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_modifySubscriberChannel",
+		Method:      http.MethodPatch,
+		Path:        "/v1/subscribers/{subscriberId}/credentials",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerModifySubscriberChannelRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerModifySubscriberChannelResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_updateSubscriberChannel",
+		Method:      http.MethodPut,
+		Path:        "/v1/subscribers/{subscriberId}/credentials",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerUpdateSubscriberChannelRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerUpdateSubscriberChannelResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "SubscribersV1Controller_updateSubscriberOnlineFlag",
+		Method:      http.MethodPatch,
+		Path:        "/v1/subscribers/{subscriberId}/online-status",
+		Request:     reflect.TypeFor[operations.SubscribersV1ControllerUpdateSubscriberOnlineFlagRequest](),
+		Response:    reflect.TypeFor[operations.SubscribersV1ControllerUpdateSubscriberOnlineFlagResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_createTopicSubscriptions",
+		Method:      http.MethodPost,
+		Path:        "/v2/topics/{topicKey}/subscriptions",
+		Request:     reflect.TypeFor[operations.TopicsControllerCreateTopicSubscriptionsRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerCreateTopicSubscriptionsResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_deleteTopic",
+		Method:      http.MethodDelete,
+		Path:        "/v2/topics/{topicKey}",
+		Request:     reflect.TypeFor[operations.TopicsControllerDeleteTopicRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerDeleteTopicResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_deleteTopicSubscriptions",
+		Method:      http.MethodDelete,
+		Path:        "/v2/topics/{topicKey}/subscriptions",
+		Request:     reflect.TypeFor[operations.TopicsControllerDeleteTopicSubscriptionsRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerDeleteTopicSubscriptionsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_getTopic",
+		Method:      http.MethodGet,
+		Path:        "/v2/topics/{topicKey}",
+		Request:     reflect.TypeFor[operations.TopicsControllerGetTopicRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerGetTopicResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_listTopicSubscriptions",
+		Method:      http.MethodGet,
+		Path:        "/v2/topics/{topicKey}/subscriptions",
+		Request:     reflect.TypeFor[operations.TopicsControllerListTopicSubscriptionsRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerListTopicSubscriptionsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_listTopics",
+		Method:      http.MethodGet,
+		Path:        "/v2/topics",
+		Request:     reflect.TypeFor[operations.TopicsControllerListTopicsRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerListTopicsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_updateTopic",
+		Method:      http.MethodPatch,
+		Path:        "/v2/topics/{topicKey}",
+		Request:     reflect.TypeFor[operations.TopicsControllerUpdateTopicRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerUpdateTopicResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsController_upsertTopic",
+		Method:      http.MethodPost,
+		Path:        "/v2/topics",
+		Request:     reflect.TypeFor[operations.TopicsControllerUpsertTopicRequest](),
+		Response:    reflect.TypeFor[operations.TopicsControllerUpsertTopicResponse](),
+		StatusCodes: []int{200, 201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "TopicsV1Controller_getTopicSubscriber",
+		Method:      http.MethodGet,
+		Path:        "/v1/topics/{topicKey}/subscribers/{externalSubscriberId}",
+		Request:     reflect.TypeFor[operations.TopicsV1ControllerGetTopicSubscriberRequest](),
+		Response:    reflect.TypeFor[operations.TopicsV1ControllerGetTopicSubscriberResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_create",
+		Method:      http.MethodPost,
+		Path:        "/v2/workflows",
+		Request:     reflect.TypeFor[operations.WorkflowControllerCreateRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerCreateResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_duplicateWorkflow",
+		Method:      http.MethodPost,
+		Path:        "/v2/workflows/{workflowId}/duplicate",
+		Request:     reflect.TypeFor[operations.WorkflowControllerDuplicateWorkflowRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerDuplicateWorkflowResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_generatePreview",
+		Method:      http.MethodPost,
+		Path:        "/v2/workflows/{workflowId}/step/{stepId}/preview",
+		Request:     reflect.TypeFor[operations.WorkflowControllerGeneratePreviewRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerGeneratePreviewResponse](),
+		StatusCodes: []int{201, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_getWorkflow",
+		Method:      http.MethodGet,
+		Path:        "/v2/workflows/{workflowId}",
+		Request:     reflect.TypeFor[operations.WorkflowControllerGetWorkflowRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerGetWorkflowResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_getWorkflowStepData",
+		Method:      http.MethodGet,
+		Path:        "/v2/workflows/{workflowId}/steps/{stepId}",
+		Request:     reflect.TypeFor[operations.WorkflowControllerGetWorkflowStepDataRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerGetWorkflowStepDataResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_patchWorkflow",
+		Method:      http.MethodPatch,
+		Path:        "/v2/workflows/{workflowId}",
+		Request:     reflect.TypeFor[operations.WorkflowControllerPatchWorkflowRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerPatchWorkflowResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_removeWorkflow",
+		Method:      http.MethodDelete,
+		Path:        "/v2/workflows/{workflowId}",
+		Request:     reflect.TypeFor[operations.WorkflowControllerRemoveWorkflowRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerRemoveWorkflowResponse](),
+		StatusCodes: []int{204, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_searchWorkflows",
+		Method:      http.MethodGet,
+		Path:        "/v2/workflows",
+		Request:     reflect.TypeFor[operations.WorkflowControllerSearchWorkflowsRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerSearchWorkflowsResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_sync",
+		Method:      http.MethodPut,
+		Path:        "/v2/workflows/{workflowId}/sync",
+		Request:     reflect.TypeFor[operations.WorkflowControllerSyncRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerSyncResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+	{
+		ID:          "WorkflowController_update",
+		Method:      http.MethodPut,
+		Path:        "/v2/workflows/{workflowId}",
+		Request:     reflect.TypeFor[operations.WorkflowControllerUpdateRequest](),
+		Response:    reflect.TypeFor[operations.WorkflowControllerUpdateResponse](),
+		StatusCodes: []int{200, 400, 401, 403, 404, 405, 409, 413, 414, 415, 422, 429, 500, 503},
+	},
+}
@@ -0,0 +1,30 @@
+package catalog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOperationID(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{method: http.MethodPost, path: "/v1/subscribers/bulk", expected: "SubscribersV1Controller_bulkCreateSubscribers"},
+		{method: http.MethodGet, path: "/v2/subscribers/sub_1", expected: "SubscribersController_getSubscriber"},
+		{method: http.MethodPost, path: "/v1/events/trigger/bulk", expected: "EventsController_triggerBulk"},
+		{method: http.MethodDelete, path: "/v1/events/trigger/txn_1", expected: "EventsController_cancel"},
+		{method: http.MethodGet, path: "/v1/unknown"},
+		{method: http.MethodPatch, path: "/v1/events/trigger"},
+	}
+
+	for _, test := range tests {
+		got, ok := OperationID(httptest.NewRequest(test.method, test.path, nil))
+
+		if got != test.expected || ok != (test.expected != "") {
+			t.Errorf("expected %s %s operation %q, got: %q (%t)", test.method, test.path, test.expected, got, ok)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package coverage reports which API operations, response status codes and
+// union variants were exercised by logged calls.
+package coverage
@@ -0,0 +1,268 @@
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+
+	"mockserver/internal/catalog"
+	"mockserver/internal/logging"
+	"mockserver/internal/sdk/utils"
+)
+
+// Report is the coverage of all API operations by logged calls.
+type Report struct {
+	Summary    Summary             `json:"summary"`
+	Operations []OperationCoverage `json:"operations"`
+	Unions     []UnionCoverage     `json:"unions"`
+}
+
+// Summary is the share of operations, documented response status codes and
+// union variants exercised.
+type Summary struct {
+	Operations    Ratio `json:"operations"`
+	StatusCodes   Ratio `json:"statusCodes"`
+	UnionVariants Ratio `json:"unionVariants"`
+}
+
+// Ratio is the number of exercised items of a total.
+type Ratio struct {
+	Hit     int     `json:"hit"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// OperationCoverage is the coverage of a single operation.
+type OperationCoverage struct {
+	ID     string `json:"operationId"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Number of calls, including calls whose logs were since removed.
+	Calls int64 `json:"calls"`
+
+	// Documented response status codes, followed by any undocumented status
+	// codes received.
+	StatusCodes []StatusCodeCoverage `json:"statusCodes"`
+}
+
+// StatusCodeCoverage is the number of logged responses with a status code.
+type StatusCodeCoverage struct {
+	Code       int  `json:"code"`
+	Documented bool `json:"documented"`
+	Calls      int  `json:"calls"`
+}
+
+// UnionCoverage is the coverage of the variants of a union type within JSON
+// request bodies and successful JSON response bodies.
+type UnionCoverage struct {
+	Name     string            `json:"name"`
+	Variants []VariantCoverage `json:"variants"`
+}
+
+// VariantCoverage is the number of logged bodies with a union variant.
+type VariantCoverage struct {
+	Name  string `json:"name"`
+	Calls int    `json:"calls"`
+}
+
+// NewReport returns the coverage of all API operations by the calls logged
+// in dir. Status codes and union variants are only counted for calls whose
+// logs are retained.
+func NewReport(dir *logging.HTTPFileDirectory) (*Report, error) {
+	result := &Report{
+		Operations: make([]OperationCoverage, 0, len(catalog.Operations)),
+		Unions:     []UnionCoverage{},
+	}
+
+	unions := make(map[string][]string)
+	seen := make(map[reflect.Type]bool)
+
+	for _, op := range catalog.Operations {
+		collectUnions(op.Request, seen, unions)
+		collectUnions(op.Response, seen, unions)
+	}
+
+	variantCalls := make(map[string]map[string]int, len(unions))
+
+	for name := range unions {
+		variantCalls[name] = make(map[string]int)
+	}
+
+	record := func(union string, variant string) {
+		if calls, ok := variantCalls[union]; ok {
+			calls[variant]++
+		}
+	}
+
+	for _, op := range catalog.Operations {
+		operationCoverage, err := newOperationCoverage(dir, op, record)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result.Operations = append(result.Operations, operationCoverage)
+		result.Summary.Operations.add(operationCoverage.Calls > 0)
+
+		for _, statusCode := range operationCoverage.StatusCodes {
+			if statusCode.Documented {
+				result.Summary.StatusCodes.add(statusCode.Calls > 0)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(unions))
+
+	for name := range unions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		unionCoverage := UnionCoverage{Name: name}
+
+		for _, variant := range unions[name] {
+			calls := variantCalls[name][variant]
+
+			unionCoverage.Variants = append(unionCoverage.Variants, VariantCoverage{Name: variant, Calls: calls})
+			result.Summary.UnionVariants.add(calls > 0)
+		}
+
+		result.Unions = append(result.Unions, unionCoverage)
+	}
+
+	return result, nil
+}
+
+// WriteFile writes the report as indented JSON to the file at path.
+func (r *Report) WriteFile(path string) error {
+	body, err := json.MarshalIndent(r, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("error encoding coverage report: %w", err)
+	}
+
+	err = os.WriteFile(path, body, 0o644)
+
+	if err != nil {
+		return fmt.Errorf("error writing coverage report %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// add counts an item, which was exercised if hit is true.
+func (r *Ratio) add(hit bool) {
+	r.Total++
+
+	if hit {
+		r.Hit++
+	}
+
+	r.Percent = math.Round(float64(r.Hit)/float64(r.Total)*1000) / 10
+}
+
+// newOperationCoverage returns the coverage of an operation by the calls
+// logged in dir, calling record for every union variant in their bodies.
+// Calls evicted while reading are skipped.
+func newOperationCoverage(dir *logging.HTTPFileDirectory, op catalog.Operation, record func(union string, variant string)) (OperationCoverage, error) {
+	result := OperationCoverage{
+		Calls:  dir.OperationCallCount(op.ID),
+		ID:     op.ID,
+		Method: op.Method,
+		Path:   op.Path,
+	}
+
+	statusCalls := make(map[int]int)
+	requestType, hasRequestBody := requestBodyType(op.Request)
+	responseType, hasResponseBody := responseBodyType(op.Response)
+
+	for _, call := range dir.OperationStoredCalls(op.ID) {
+		req, err := dir.Request(op.ID, call)
+
+		if logging.IsEvicted(err) {
+			continue
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		if hasRequestBody {
+			recordBodyUnionVariants(req.Body, requestType, record)
+		}
+
+		resp, err := dir.Response(op.ID, call)
+
+		if logging.IsEvicted(err) {
+			continue
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		statusCalls[resp.StatusCode]++
+
+		if hasResponseBody && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+			recordBodyUnionVariants(resp.Body, responseType, record)
+		}
+	}
+
+	for _, code := range op.StatusCodes {
+		result.StatusCodes = append(result.StatusCodes, StatusCodeCoverage{
+			Calls:      statusCalls[code],
+			Code:       code,
+			Documented: true,
+		})
+	}
+
+	var undocumented []int
+
+	for code := range statusCalls {
+		if !slices.Contains(op.StatusCodes, code) {
+			undocumented = append(undocumented, code)
+		}
+	}
+
+	sort.Ints(undocumented)
+
+	for _, code := range undocumented {
+		result.StatusCodes = append(result.StatusCodes, StatusCodeCoverage{
+			Calls: statusCalls[code],
+			Code:  code,
+		})
+	}
+
+	return result, nil
+}
+
+// recordBodyUnionVariants decodes the JSON body as t and calls record for
+// every union variant within it. Bodies which cannot be decoded are ignored.
+func recordBodyUnionVariants(body io.Reader, t reflect.Type, record func(union string, variant string)) {
+	if body == nil {
+		return
+	}
+
+	data, err := io.ReadAll(body)
+
+	if err != nil || len(data) == 0 {
+		return
+	}
+
+	value := reflect.New(t)
+
+	if utils.UnmarshalJSON(data, value.Interface(), "", true, false) != nil {
+		return
+	}
+
+	recordUnionVariants(value, record)
+}
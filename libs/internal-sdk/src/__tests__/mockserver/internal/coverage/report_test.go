@@ -0,0 +1,159 @@
+package coverage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"mockserver/internal/catalog"
+	"mockserver/internal/logging"
+)
+
+// serveCall logs a call of the operation with the JSON request body, which is
+// answered with status.
+func serveCall(t *testing.T, dir *logging.HTTPFileDirectory, op catalog.Operation, body string, status int) {
+	t.Helper()
+
+	handler := dir.HandlerFunc(op.ID, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	req := httptest.NewRequest(op.Method, op.Path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+
+	handler(httptest.NewRecorder(), req)
+}
+
+// findOperation returns the catalog operation with the identifier.
+func findOperation(t *testing.T, id string) catalog.Operation {
+	t.Helper()
+
+	for _, op := range catalog.Operations {
+		if op.ID == id {
+			return op
+		}
+	}
+
+	t.Fatalf("unknown operation %s", id)
+
+	return catalog.Operation{}
+}
+
+func TestNewReport(t *testing.T) {
+	dir, err := logging.NewHTTPFileDirectory(t.TempDir())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	trigger := findOperation(t, "EventsController_trigger")
+
+	serveCall(t, dir, trigger, `{"name":"welcome","to":"alice"}`, http.StatusCreated)
+	serveCall(t, dir, trigger, `{"name":"welcome","to":{"subscriberId":"bob"}}`, http.StatusCreated)
+	serveCall(t, dir, trigger, `{"name":"welcome","to":["alice","bob"]}`, http.StatusUnprocessableEntity)
+	serveCall(t, dir, trigger, `{"name":"welcome","to":"alice"}`, http.StatusTeapot)
+
+	report, err := NewReport(dir)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var operation *OperationCoverage
+
+	for i := range report.Operations {
+		if report.Operations[i].ID == trigger.ID {
+			operation = &report.Operations[i]
+		}
+	}
+
+	if operation == nil || operation.Calls != 4 {
+		t.Fatalf("expected %s with 4 calls, got: %+v", trigger.ID, operation)
+	}
+
+	statusCalls := make(map[int]StatusCodeCoverage)
+
+	for _, statusCode := range operation.StatusCodes {
+		statusCalls[statusCode.Code] = statusCode
+	}
+
+	for _, expected := range []StatusCodeCoverage{
+		{Code: http.StatusCreated, Documented: true, Calls: 2},
+		{Code: http.StatusUnprocessableEntity, Documented: true, Calls: 1},
+		{Code: http.StatusNotFound, Documented: true, Calls: 0},
+		{Code: http.StatusTeapot, Documented: false, Calls: 1},
+	} {
+		if got := statusCalls[expected.Code]; got != expected {
+			t.Errorf("expected status code coverage %+v, got: %+v", expected, got)
+		}
+	}
+
+	if len(operation.StatusCodes) != len(trigger.StatusCodes)+1 {
+		t.Errorf("expected %d documented and 1 undocumented status codes, got: %+v", len(trigger.StatusCodes), operation.StatusCodes)
+	}
+
+	expectedVariants := map[string]map[string]int{
+		"components.ToUnion2": {"ArrayOfToUnion1": 1, "Str": 2, "SubscriberPayloadDto": 1, "TopicPayloadDto": 0},
+		"components.ToUnion1": {"Str": 2, "SubscriberPayloadDto": 0, "TopicPayloadDto": 0},
+	}
+
+	for _, union := range report.Unions {
+		expected, ok := expectedVariants[union.Name]
+
+		if !ok {
+			continue
+		}
+
+		delete(expectedVariants, union.Name)
+
+		for _, variant := range union.Variants {
+			if variant.Calls != expected[variant.Name] {
+				t.Errorf("expected %s variant %s calls %d, got: %d", union.Name, variant.Name, expected[variant.Name], variant.Calls)
+			}
+		}
+	}
+
+	if len(expectedVariants) > 0 {
+		t.Errorf("expected unions %v in report", expectedVariants)
+	}
+
+	summary := report.Summary
+
+	if summary.Operations.Hit != 1 || summary.Operations.Total != len(catalog.Operations) {
+		t.Errorf("expected 1 of %d operations hit, got: %+v", len(catalog.Operations), summary.Operations)
+	}
+
+	if summary.StatusCodes.Hit != 2 {
+		t.Errorf("expected 2 documented status codes hit, got: %+v", summary.StatusCodes)
+	}
+
+	if summary.UnionVariants.Hit == 0 || summary.UnionVariants.Total <= summary.UnionVariants.Hit {
+		t.Errorf("expected some union variants hit, got: %+v", summary.UnionVariants)
+	}
+
+	path := filepath.Join(t.TempDir(), "coverage.json")
+
+	if err := report.WriteFile(path); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var written Report
+
+	if err := json.Unmarshal(data, &written); err != nil || written.Summary != report.Summary {
+		t.Errorf("expected written report summary %+v, got: %+v %v", report.Summary, written.Summary, err)
+	}
+}
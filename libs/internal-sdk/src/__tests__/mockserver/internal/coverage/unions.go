@@ -0,0 +1,158 @@
+package coverage
+
+import (
+	"reflect"
+	"strings"
+)
+
+// unionVariants returns the variant field names of a generated union type,
+// which is a struct of variant fields tagged queryParam:"inline" and a Type
+// field naming the decoded variant.
+func unionVariants(t reflect.Type) ([]string, bool) {
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	typeField, ok := t.FieldByName("Type")
+
+	if !ok || typeField.Type.Kind() != reflect.String {
+		return nil, false
+	}
+
+	var result []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Tag.Get("queryParam") == "inline" {
+			result = append(result, field.Name)
+		}
+	}
+
+	return result, len(result) > 0
+}
+
+// collectUnions adds the variants of every union type reachable from t to
+// result, keyed by union type name.
+func collectUnions(t reflect.Type, seen map[reflect.Type]bool, result map[string][]string) {
+	switch t.Kind() {
+	case reflect.Array, reflect.Map, reflect.Pointer, reflect.Slice:
+		collectUnions(t.Elem(), seen, result)
+	case reflect.Struct:
+		if seen[t] {
+			return
+		}
+
+		seen[t] = true
+
+		if variants, ok := unionVariants(t); ok {
+			result[t.String()] = variants
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				collectUnions(t.Field(i).Type, seen, result)
+			}
+		}
+	}
+}
+
+// recordUnionVariants calls record with the union type name and variant field
+// name of every decoded union value within v.
+func recordUnionVariants(v reflect.Value, record func(union string, variant string)) {
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if !v.IsNil() {
+			recordUnionVariants(v.Elem(), record)
+		}
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			recordUnionVariants(v.Index(i), record)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+
+		for iter.Next() {
+			recordUnionVariants(iter.Value(), record)
+		}
+	case reflect.Struct:
+		t := v.Type()
+
+		if variants, ok := unionVariants(t); ok {
+			if variant, ok := decodedUnionVariant(v, variants); ok {
+				record(t.String(), variant)
+				recordUnionVariants(v.FieldByName(variant), record)
+			}
+
+			return
+		}
+
+		for i := 0; i < v.NumField(); i++ {
+			if t.Field(i).IsExported() {
+				recordUnionVariants(v.Field(i), record)
+			}
+		}
+	}
+}
+
+// decodedUnionVariant returns the variant field name of a decoded union
+// value. The Type field names the variant, either as the field name, ignoring
+// case and underscores, or as a discriminator value, in which case the only set
+// variant field is used.
+func decodedUnionVariant(v reflect.Value, variants []string) (string, bool) {
+	typeName := strings.ReplaceAll(v.FieldByName("Type").String(), "_", "")
+
+	if typeName == "" {
+		return "", false
+	}
+
+	for _, variant := range variants {
+		if strings.EqualFold(variant, typeName) {
+			return variant, true
+		}
+	}
+
+	var result string
+
+	for _, variant := range variants {
+		if v.FieldByName(variant).IsZero() {
+			continue
+		}
+
+		if result != "" {
+			return "", false
+		}
+
+		result = variant
+	}
+
+	return result, result != ""
+}
+
+// requestBodyType returns the JSON request body type of an operations
+// package request type.
+func requestBodyType(request reflect.Type) (reflect.Type, bool) {
+	for i := 0; i < request.NumField(); i++ {
+		field := request.Field(i)
+
+		if strings.Contains(field.Tag.Get("request"), "mediaType=application/json") {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+// responseBodyType returns the successful response body type of an
+// operations package response type.
+func responseBodyType(response reflect.Type) (reflect.Type, bool) {
+	for i := 0; i < response.NumField(); i++ {
+		field := response.Field(i)
+
+		if field.Name != "HTTPMeta" && field.Name != "Headers" {
+			return field.Type, true
+		}
+	}
+
+	return nil, false
+}
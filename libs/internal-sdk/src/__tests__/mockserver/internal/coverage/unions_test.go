@@ -0,0 +1,90 @@
+package coverage
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestUnionVariants(t *testing.T) {
+	type union struct {
+		A    *string `queryParam:"inline"`
+		B    *int    `queryParam:"inline"`
+		C    *bool
+		Type string
+	}
+
+	type noType struct {
+		A *string `queryParam:"inline"`
+	}
+
+	type intType struct {
+		A    *string `queryParam:"inline"`
+		Type int
+	}
+
+	type noVariants struct {
+		A    *string
+		Type string
+	}
+
+	for _, test := range []struct {
+		name     string
+		t        reflect.Type
+		expected []string
+	}{
+		{name: "union", t: reflect.TypeFor[union](), expected: []string{"A", "B"}},
+		{name: "generated union", t: reflect.TypeFor[components.ToUnion2](), expected: []string{"ArrayOfToUnion1", "Str", "SubscriberPayloadDto", "TopicPayloadDto"}},
+		{name: "without Type field", t: reflect.TypeFor[noType](), expected: nil},
+		{name: "non-string Type field", t: reflect.TypeFor[intType](), expected: nil},
+		{name: "without inline fields", t: reflect.TypeFor[noVariants](), expected: nil},
+		{name: "pointer", t: reflect.TypeFor[*union](), expected: nil},
+		{name: "generated model", t: reflect.TypeFor[components.TriggerEventRequestDto](), expected: nil},
+	} {
+		got, ok := unionVariants(test.t)
+
+		if ok != (test.expected != nil) || !slices.Equal(got, test.expected) {
+			t.Errorf("%s: expected variants %v, got: %v %t", test.name, test.expected, got, ok)
+		}
+	}
+}
+
+func TestDecodedUnionVariant(t *testing.T) {
+	type union struct {
+		EmailStep *string `queryParam:"inline"`
+		SmsStep   *string `queryParam:"inline"`
+		Type      string
+	}
+
+	text := "text"
+	variants := []string{"EmailStep", "SmsStep"}
+
+	for _, test := range []struct {
+		name     string
+		value    any
+		variants []string
+		expected string
+	}{
+		{name: "field name", value: union{SmsStep: &text, Type: "SmsStep"}, variants: variants, expected: "SmsStep"},
+		{name: "field name ignoring case and underscores", value: union{Type: "email_step"}, variants: variants, expected: "EmailStep"},
+		{name: "discriminator", value: union{SmsStep: &text, Type: "sms"}, variants: variants, expected: "SmsStep"},
+		{name: "discriminator without set variant", value: union{Type: "sms"}, variants: variants, expected: ""},
+		{name: "discriminator with several set variants", value: union{EmailStep: &text, SmsStep: &text, Type: "sms"}, variants: variants, expected: ""},
+		{name: "empty type", value: union{SmsStep: &text}, variants: variants, expected: ""},
+		{name: "generated union", value: components.CreateToUnion2Str("alice"), variants: []string{"ArrayOfToUnion1", "Str", "SubscriberPayloadDto", "TopicPayloadDto"}, expected: "Str"},
+		{
+			name:     "generated discriminated union",
+			value:    components.CreateWorkflowResponseDtoStepInApp(components.InAppStepResponseDto{}),
+			variants: []string{"InAppStepResponseDto", "EmailStepResponseDto", "SmsStepResponseDto"},
+			expected: "InAppStepResponseDto",
+		},
+	} {
+		got, ok := decodedUnionVariant(reflect.ValueOf(test.value), test.variants)
+
+		if got != test.expected || ok != (test.expected != "") {
+			t.Errorf("%s: expected variant %q, got: %q %t", test.name, test.expected, got, ok)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+// Package expectation contains call expectations registered by tests and their
+// verification against received requests.
+package expectation
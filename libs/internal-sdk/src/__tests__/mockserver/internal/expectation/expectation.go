@@ -0,0 +1,224 @@
+package expectation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"mockserver/internal/handler/assert"
+)
+
+// Expectation describes calls a test expects the server to receive.
+type Expectation struct {
+	// Unique identifier. Generated if not set on creation.
+	ID string `json:"id"`
+
+	// Test namespace, as sent via the tracking.TestInstanceIDHeader request
+	// header. Empty matches requests of any namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// HTTP method, such as POST.
+	Method string `json:"method"`
+
+	// URL path, such as /v1/events/trigger. Segments in braces, such as
+	// {subscriberId}, match any single segment.
+	Path string `json:"path"`
+
+	// Request headers which must have exactly the given values.
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Query parameters which must have exactly the given values.
+	Query map[string][]string `json:"query,omitempty"`
+
+	// Request Content-Type, compared by media type.
+	ContentType string `json:"contentType,omitempty"`
+
+	// JSON value the request body must contain. Objects match if every
+	// expected property matches, arrays if every element matches in order.
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// Number of matching calls. Defaults to at least once.
+	Times Times `json:"times"`
+
+	// Number of matching calls received since the expectation was added.
+	Count int `json:"count"`
+}
+
+// Times is the number of calls an Expectation requires. Exactly cannot be
+// combined with AtLeast or AtMost. If none are set, at least one call is
+// required.
+type Times struct {
+	Exactly *int `json:"exactly,omitempty"`
+	AtLeast *int `json:"atLeast,omitempty"`
+	AtMost  *int `json:"atMost,omitempty"`
+}
+
+// String returns a readable description, such as "exactly 2 times".
+func (t Times) String() string {
+	switch {
+	case t.Exactly != nil:
+		return fmt.Sprintf("exactly %d %s", *t.Exactly, pluralTimes(*t.Exactly))
+	case t.AtLeast != nil && t.AtMost != nil:
+		return fmt.Sprintf("between %d and %d times", *t.AtLeast, *t.AtMost)
+	case t.AtMost != nil:
+		return fmt.Sprintf("at most %d %s", *t.AtMost, pluralTimes(*t.AtMost))
+	case t.AtLeast != nil:
+		return fmt.Sprintf("at least %d %s", *t.AtLeast, pluralTimes(*t.AtLeast))
+	default:
+		return "at least 1 time"
+	}
+}
+
+// satisfied returns whether count meets the required number of calls.
+func (t Times) satisfied(count int) bool {
+	if t.Exactly != nil {
+		return count == *t.Exactly
+	}
+
+	if t.AtMost != nil && count > *t.AtMost {
+		return false
+	}
+
+	if t.AtLeast != nil {
+		return count >= *t.AtLeast
+	}
+
+	return t.AtMost != nil || count >= 1
+}
+
+// String returns a readable description, such as
+// "POST /v1/events/trigger exactly 2 times".
+func (e *Expectation) String() string {
+	result := e.Method + " " + e.Path
+
+	if e.Namespace != "" {
+		result += " in namespace " + e.Namespace
+	}
+
+	return result + " " + e.Times.String()
+}
+
+// validate verifies the expectation is complete and consistent.
+func (e *Expectation) validate() error {
+	if e.Method == "" {
+		return errors.New("method is required")
+	}
+
+	if !strings.HasPrefix(e.Path, "/") {
+		return fmt.Errorf("path %q must start with /", e.Path)
+	}
+
+	if e.Body != nil && !json.Valid(e.Body) {
+		return errors.New("body must be valid JSON")
+	}
+
+	if e.Times.Exactly != nil && (e.Times.AtLeast != nil || e.Times.AtMost != nil) {
+		return errors.New("times.exactly cannot be combined with times.atLeast or times.atMost")
+	}
+
+	for name, value := range map[string]*int{"exactly": e.Times.Exactly, "atLeast": e.Times.AtLeast, "atMost": e.Times.AtMost} {
+		if value != nil && *value < 0 {
+			return fmt.Errorf("times.%s must not be negative", name)
+		}
+	}
+
+	if e.Times.AtLeast != nil && e.Times.AtMost != nil && *e.Times.AtLeast > *e.Times.AtMost {
+		return errors.New("times.atLeast must not exceed times.atMost")
+	}
+
+	return nil
+}
+
+// targets returns whether the request has the expected namespace, method and
+// path, which makes any further mismatch worth reporting.
+func (e *Expectation) targets(req *http.Request, namespace string) bool {
+	if e.Namespace != "" && e.Namespace != namespace {
+		return false
+	}
+
+	if !strings.EqualFold(e.Method, req.Method) {
+		return false
+	}
+
+	return matchPath(e.Path, req.URL.Path)
+}
+
+// mismatches returns the reasons the request does not match the expected
+// headers, query parameters, content type and body. The request must already
+// be targeted by the expectation.
+func (e *Expectation) mismatches(req *http.Request, body []byte) []string {
+	var result []string
+
+	for name, values := range e.Headers {
+		if err := assert.HeaderValues(req, name, values); err != nil {
+			result = append(result, err.Error())
+		}
+	}
+
+	for key, values := range e.Query {
+		if err := assert.ParameterQueryValues(req, key, values); err != nil {
+			result = append(result, err.Error())
+		}
+	}
+
+	if e.ContentType != "" {
+		if err := assert.ContentType(req, e.ContentType, true); err != nil {
+			result = append(result, err.Error())
+		}
+	}
+
+	if e.Body != nil {
+		var expected, got any
+
+		_ = json.Unmarshal(e.Body, &expected)
+
+		if err := json.Unmarshal(body, &got); err != nil {
+			result = append(result, fmt.Sprintf("expected JSON body, got: %q", truncate(string(body), 200)))
+		} else {
+			result = append(result, jsonSubsetDiff("$", expected, got)...)
+		}
+	}
+
+	return result
+}
+
+// matchPath returns whether path matches the template, where segments in
+// braces match any single segment.
+func matchPath(template string, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(templateSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") && pathSegments[i] != "" {
+			continue
+		}
+
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func pluralTimes(n int) string {
+	if n == 1 {
+		return "time"
+	}
+
+	return "times"
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n] + "..."
+}
@@ -0,0 +1,79 @@
+package expectation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimes(t *testing.T) {
+	n := func(v int) *int {
+		return &v
+	}
+
+	for _, test := range []struct {
+		times     Times
+		expected  string
+		satisfied []int
+		unmet     []int
+	}{
+		{times: Times{}, expected: "at least 1 time", satisfied: []int{1, 5}, unmet: []int{0}},
+		{times: Times{Exactly: n(0)}, expected: "exactly 0 times", satisfied: []int{0}, unmet: []int{1}},
+		{times: Times{Exactly: n(2)}, expected: "exactly 2 times", satisfied: []int{2}, unmet: []int{1, 3}},
+		{times: Times{AtLeast: n(0)}, expected: "at least 0 times", satisfied: []int{0, 1}, unmet: nil},
+		{times: Times{AtLeast: n(2)}, expected: "at least 2 times", satisfied: []int{2, 3}, unmet: []int{0, 1}},
+		{times: Times{AtMost: n(1)}, expected: "at most 1 time", satisfied: []int{0, 1}, unmet: []int{2}},
+		{times: Times{AtMost: n(0)}, expected: "at most 0 times", satisfied: []int{0}, unmet: []int{1}},
+		{times: Times{AtLeast: n(1), AtMost: n(2)}, expected: "between 1 and 2 times", satisfied: []int{1, 2}, unmet: []int{0, 3}},
+		{times: Times{AtLeast: n(2), AtMost: n(2)}, expected: "between 2 and 2 times", satisfied: []int{2}, unmet: []int{1, 3}},
+	} {
+		if got := test.times.String(); got != test.expected {
+			t.Errorf("expected %q, got: %q", test.expected, got)
+		}
+
+		for _, count := range test.satisfied {
+			if !test.times.satisfied(count) {
+				t.Errorf("%s: expected count %d to be satisfied", test.expected, count)
+			}
+		}
+
+		for _, count := range test.unmet {
+			if test.times.satisfied(count) {
+				t.Errorf("%s: expected count %d to be unmet", test.expected, count)
+			}
+		}
+	}
+}
+
+func TestExpectationValidate(t *testing.T) {
+	n := func(v int) *int {
+		return &v
+	}
+
+	for _, test := range []struct {
+		expectation Expectation
+		expected    string
+	}{
+		{expectation: Expectation{Method: "GET", Path: "/v1/environments"}, expected: ""},
+		{expectation: Expectation{Path: "/v1/environments"}, expected: "method is required"},
+		{expectation: Expectation{Method: "GET", Path: "v1/environments"}, expected: `path "v1/environments" must start with /`},
+		{expectation: Expectation{Method: "GET", Path: ""}, expected: `path "" must start with /`},
+		{expectation: Expectation{Method: "POST", Path: "/", Body: []byte(`{`)}, expected: "body must be valid JSON"},
+		{expectation: Expectation{Method: "GET", Path: "/", Times: Times{Exactly: n(1), AtMost: n(2)}}, expected: "times.exactly cannot be combined"},
+		{expectation: Expectation{Method: "GET", Path: "/", Times: Times{AtLeast: n(-1)}}, expected: "times.atLeast must not be negative"},
+		{expectation: Expectation{Method: "GET", Path: "/", Times: Times{AtLeast: n(3), AtMost: n(2)}}, expected: "times.atLeast must not exceed times.atMost"},
+	} {
+		err := test.expectation.validate()
+
+		if test.expected == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", &test.expectation, err)
+			}
+
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected error %q, got: %v", &test.expectation, test.expected, err)
+		}
+	}
+}
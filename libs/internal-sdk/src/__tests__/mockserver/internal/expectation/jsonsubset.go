@@ -0,0 +1,83 @@
+package expectation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// jsonSubsetDiff returns a readable difference for each place where got does
+// not contain expected, identified by JSON path. Objects contain expected
+// objects if every expected property is contained, and arrays contain expected
+// arrays of the same length if every element is contained in order.
+func jsonSubsetDiff(path string, expected any, got any) []string {
+	switch expectedValue := expected.(type) {
+	case map[string]any:
+		gotValue, ok := got.(map[string]any)
+
+		if !ok {
+			return []string{fmt.Sprintf("body %s: expected object, got %s", path, jsonString(got))}
+		}
+
+		keys := make([]string, 0, len(expectedValue))
+
+		for key := range expectedValue {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		var result []string
+
+		for _, key := range keys {
+			itemPath := path + "." + key
+			gotItem, ok := gotValue[key]
+
+			if !ok {
+				result = append(result, fmt.Sprintf("body %s: expected %s, got nothing", itemPath, jsonString(expectedValue[key])))
+
+				continue
+			}
+
+			result = append(result, jsonSubsetDiff(itemPath, expectedValue[key], gotItem)...)
+		}
+
+		return result
+	case []any:
+		gotValue, ok := got.([]any)
+
+		if !ok {
+			return []string{fmt.Sprintf("body %s: expected array, got %s", path, jsonString(got))}
+		}
+
+		if len(gotValue) != len(expectedValue) {
+			return []string{fmt.Sprintf("body %s: expected %d elements, got %d", path, len(expectedValue), len(gotValue))}
+		}
+
+		var result []string
+
+		for i := range expectedValue {
+			result = append(result, jsonSubsetDiff(fmt.Sprintf("%s[%d]", path, i), expectedValue[i], gotValue[i])...)
+		}
+
+		return result
+	default:
+		if !reflect.DeepEqual(expected, got) {
+			return []string{fmt.Sprintf("body %s: expected %s, got %s", path, jsonString(expected), jsonString(got))}
+		}
+
+		return nil
+	}
+}
+
+// jsonString returns the compact JSON encoding of a decoded value.
+func jsonString(v any) string {
+	result, err := json.Marshal(v)
+
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return truncate(string(result), 200)
+}
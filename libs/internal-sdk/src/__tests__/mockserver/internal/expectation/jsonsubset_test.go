@@ -0,0 +1,43 @@
+package expectation
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestJSONSubsetDiff(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		expected string
+		got      string
+		diff     []string
+	}{
+		{name: "equal scalar", expected: `1`, got: `1`, diff: nil},
+		{name: "different scalar", expected: `"a"`, got: `"b"`, diff: []string{`body $: expected "a", got "b"`}},
+		{name: "different type", expected: `1`, got: `"1"`, diff: []string{`body $: expected 1, got "1"`}},
+		{name: "null", expected: `null`, got: `{}`, diff: []string{`body $: expected null, got {}`}},
+		{name: "object subset", expected: `{"a":1}`, got: `{"a":1,"b":2}`, diff: nil},
+		{name: "object expected", expected: `{"a":1}`, got: `[1]`, diff: []string{`body $: expected object, got [1]`}},
+		{name: "missing properties sorted", expected: `{"b":2,"a":1}`, got: `{}`, diff: []string{`body $.a: expected 1, got nothing`, `body $.b: expected 2, got nothing`}},
+		{name: "nested object", expected: `{"to":{"subscriberId":"alice"}}`, got: `{"to":{"subscriberId":"bob","email":"bob@example.com"}}`, diff: []string{`body $.to.subscriberId: expected "alice", got "bob"`}},
+		{name: "array in order", expected: `[1,{"a":1}]`, got: `[1,{"a":1,"b":2}]`, diff: nil},
+		{name: "array expected", expected: `[1]`, got: `{"0":1}`, diff: []string{`body $: expected array, got {"0":1}`}},
+		{name: "array length", expected: `{"a":[1]}`, got: `{"a":[1,2]}`, diff: []string{`body $.a: expected 1 elements, got 2`}},
+		{name: "array elements", expected: `[1,2,3]`, got: `[1,3,2]`, diff: []string{`body $[1]: expected 2, got 3`, `body $[2]: expected 3, got 2`}},
+	} {
+		var expected, got any
+
+		if err := json.Unmarshal([]byte(test.expected), &expected); err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, err)
+		}
+
+		if err := json.Unmarshal([]byte(test.got), &got); err != nil {
+			t.Fatalf("%s: unexpected error: %s", test.name, err)
+		}
+
+		if diff := jsonSubsetDiff("$", expected, got); !slices.Equal(diff, test.diff) {
+			t.Errorf("%s: expected diff %q, got: %q", test.name, test.diff, diff)
+		}
+	}
+}
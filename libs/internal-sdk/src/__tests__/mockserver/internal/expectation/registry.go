@@ -0,0 +1,237 @@
+package expectation
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"mockserver/internal/tracking"
+)
+
+const (
+	// Maximum number of near misses kept per expectation.
+	maxNearMisses = 5
+
+	// Maximum number of unexpected calls kept.
+	maxUnexpectedCalls = 1000
+)
+
+// Call is a received request relevant to verification.
+type Call struct {
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Namespace string    `json:"namespace,omitempty"`
+	Time      time.Time `json:"time"`
+
+	// Reasons the call did not match an expectation with the same namespace,
+	// method and path.
+	Mismatches []string `json:"mismatches,omitempty"`
+}
+
+// String returns a readable description, such as
+// "POST /v1/events/trigger at 2006-01-02T15:04:05Z".
+func (c Call) String() string {
+	result := c.Method + " " + c.Path
+
+	if c.Namespace != "" {
+		result += " in namespace " + c.Namespace
+	}
+
+	return result + " at " + c.Time.Format(time.RFC3339Nano)
+}
+
+// Registry holds registered expectations and records received requests
+// against them. Match counts are kept until the expectation is cleared. It is
+// safe for concurrent use.
+type Registry struct {
+	mutex        sync.Mutex
+	expectations []*Expectation
+	nearMisses   map[string][]Call
+	nextID       int
+	unexpected   []Call
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		nearMisses: make(map[string][]Call),
+	}
+}
+
+// Add registers the expectation, generating its ID if not set.
+func (r *Registry) Add(e Expectation) (*Expectation, error) {
+	if err := e.validate(); err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if e.ID == "" {
+		r.nextID++
+		e.ID = "exp_" + strconv.Itoa(r.nextID)
+	}
+
+	for _, existing := range r.expectations {
+		if existing.ID == e.ID {
+			return nil, fmt.Errorf("expectation %s already exists", e.ID)
+		}
+	}
+
+	e.Count = 0
+	r.expectations = append(r.expectations, &e)
+
+	result := e
+
+	return &result, nil
+}
+
+// Clear removes expectations and recorded calls of the namespace, or all of
+// them if namespace is empty.
+func (r *Registry) Clear(namespace string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if namespace == "" {
+		r.expectations = nil
+		r.nearMisses = make(map[string][]Call)
+		r.unexpected = nil
+
+		return
+	}
+
+	var expectations []*Expectation
+
+	for _, e := range r.expectations {
+		if e.Namespace == namespace {
+			delete(r.nearMisses, e.ID)
+
+			continue
+		}
+
+		expectations = append(expectations, e)
+	}
+
+	r.expectations = expectations
+
+	var unexpected []Call
+
+	for _, call := range r.unexpected {
+		if call.Namespace != namespace {
+			unexpected = append(unexpected, call)
+		}
+	}
+
+	r.unexpected = unexpected
+}
+
+// Empty returns whether no expectations are registered.
+func (r *Registry) Empty() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.expectations) == 0
+}
+
+// List returns the expectations of the namespace, or all of them if
+// namespace is empty, with their current counts.
+func (r *Registry) List(namespace string) []*Expectation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := []*Expectation{}
+
+	for _, e := range r.expectations {
+		if namespace == "" || e.Namespace == namespace {
+			counted := *e
+			result = append(result, &counted)
+		}
+	}
+
+	return result
+}
+
+// Record matches the request and its body against the registered
+// expectations. Requests matching none are recorded as unexpected.
+func (r *Registry) Record(req *http.Request, body []byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	namespace := req.Header.Get(tracking.TestInstanceIDHeader)
+	call := Call{
+		Method:    req.Method,
+		Path:      req.URL.Path,
+		Namespace: namespace,
+		Time:      time.Now().UTC(),
+	}
+	matched := false
+
+	for _, e := range r.expectations {
+		if !e.targets(req, namespace) {
+			continue
+		}
+
+		mismatches := e.mismatches(req, body)
+
+		if len(mismatches) > 0 {
+			if len(r.nearMisses[e.ID]) < maxNearMisses {
+				nearMiss := call
+				nearMiss.Mismatches = mismatches
+				r.nearMisses[e.ID] = append(r.nearMisses[e.ID], nearMiss)
+			}
+
+			continue
+		}
+
+		e.Count++
+		matched = true
+	}
+
+	if !matched && len(r.unexpected) < maxUnexpectedCalls {
+		r.unexpected = append(r.unexpected, call)
+	}
+}
+
+// Verify checks the expectations and calls of the namespace, or all of them
+// if namespace is empty.
+func (r *Registry) Verify(namespace string) *Report {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := &Report{
+		Unexpected: []Call{},
+		Unmet:      []UnmetExpectation{},
+	}
+
+	for _, e := range r.expectations {
+		if namespace != "" && e.Namespace != namespace {
+			continue
+		}
+
+		result.Expectations++
+
+		if e.Times.satisfied(e.Count) {
+			continue
+		}
+
+		counted := *e
+
+		result.Unmet = append(result.Unmet, UnmetExpectation{
+			Expectation: &counted,
+			NearMisses:  append([]Call{}, r.nearMisses[e.ID]...),
+		})
+	}
+
+	for _, call := range r.unexpected {
+		if namespace == "" || call.Namespace == namespace {
+			result.Unexpected = append(result.Unexpected, call)
+		}
+	}
+
+	result.Satisfied = len(result.Unmet) == 0 && len(result.Unexpected) == 0
+	result.Diff = result.diff()
+
+	return result
+}
@@ -0,0 +1,134 @@
+package expectation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mockserver/internal/tracking"
+)
+
+func TestRegistryCount(t *testing.T) {
+	r := NewRegistry()
+
+	if _, err := r.Add(Expectation{ID: "events", Method: http.MethodPost, Path: "/v1/events"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for range 2 {
+		r.Record(httptest.NewRequest(http.MethodPost, "/v1/events", nil), nil)
+	}
+
+	r.Record(httptest.NewRequest(http.MethodGet, "/v1/events", nil), nil)
+
+	if got := r.List("")[0].Count; got != 2 {
+		t.Errorf("expected count 2, got: %d", got)
+	}
+
+	if report := r.Verify(""); report.Satisfied || len(report.Unexpected) != 1 {
+		t.Errorf("expected unsatisfied report with 1 unexpected call, got: %+v", report)
+	}
+
+	// Adding the expectation again after clearing starts from zero.
+	r.Clear("")
+
+	if _, err := r.Add(Expectation{ID: "events", Method: http.MethodPost, Path: "/v1/events"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got := r.List("")[0].Count; got != 0 {
+		t.Errorf("expected count 0 after clearing, got: %d", got)
+	}
+
+	r.Record(httptest.NewRequest(http.MethodPost, "/v1/events", nil), nil)
+
+	if report := r.Verify(""); !report.Satisfied {
+		t.Errorf("expected satisfied report, got: %+v", report)
+	}
+}
+
+func TestRegistryNearMisses(t *testing.T) {
+	r := NewRegistry()
+
+	e, err := r.Add(Expectation{
+		Namespace:   "test-1",
+		Method:      http.MethodPost,
+		Path:        "/v2/subscribers/{subscriberId}",
+		Headers:     map[string][]string{"X-Request": {"expected"}},
+		Query:       map[string][]string{"page": {"1"}},
+		ContentType: "application/json",
+		Body:        []byte(`{"firstName":"Alice"}`),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	newRequest := func(namespace string, method string, target string, header string, body string) *http.Request {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set(tracking.TestInstanceIDHeader, namespace)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request", header)
+
+		return req
+	}
+
+	// Calls in other namespaces, or with other methods or paths, are not near
+	// misses.
+	for _, req := range []*http.Request{
+		newRequest("test-2", http.MethodPost, "/v2/subscribers/alice?page=1", "expected", `{}`),
+		newRequest("test-1", http.MethodPatch, "/v2/subscribers/alice?page=1", "expected", `{}`),
+		newRequest("test-1", http.MethodPost, "/v2/subscribers/alice/credentials?page=1", "expected", `{}`),
+	} {
+		r.Record(req, []byte(`{}`))
+	}
+
+	body := `{"firstName":"Bob"}`
+	r.Record(newRequest("test-1", http.MethodPost, "/v2/subscribers/alice?page=2", "other", body), []byte(body))
+
+	report := r.Verify("test-1")
+
+	if len(report.Unmet) != 1 || report.Unmet[0].Expectation.ID != e.ID {
+		t.Fatalf("expected unmet expectation %s, got: %+v", e.ID, report.Unmet)
+	}
+
+	nearMisses := report.Unmet[0].NearMisses
+
+	if len(nearMisses) != 1 {
+		t.Fatalf("expected 1 near miss, got: %+v", nearMisses)
+	}
+
+	mismatches := strings.Join(nearMisses[0].Mismatches, "\n")
+
+	for _, expected := range []string{"X-Request", "page", `body $.firstName: expected "Alice", got "Bob"`} {
+		if !strings.Contains(mismatches, expected) {
+			t.Errorf("expected mismatch %q, got: %s", expected, mismatches)
+		}
+	}
+
+	// Near misses are also unexpected calls of their namespace.
+	if len(report.Unexpected) != 3 {
+		t.Errorf("expected 3 unexpected calls in namespace test-1, got: %+v", report.Unexpected)
+	}
+
+	for range maxNearMisses {
+		r.Record(newRequest("test-1", http.MethodPost, "/v2/subscribers/alice?page=1", "expected", "not json"), []byte("not json"))
+	}
+
+	nearMisses = r.Verify("test-1").Unmet[0].NearMisses
+
+	if len(nearMisses) != maxNearMisses {
+		t.Fatalf("expected %d near misses, got: %d", maxNearMisses, len(nearMisses))
+	}
+
+	if got := nearMisses[1].Mismatches; len(got) != 1 || got[0] != `expected JSON body, got: "not json"` {
+		t.Errorf("expected only a JSON body mismatch, got: %q", got)
+	}
+
+	r.Record(newRequest("test-1", http.MethodPost, "/v2/subscribers/alice?page=1", "expected", `{"firstName":"Alice","lastName":"A"}`), []byte(`{"firstName":"Alice","lastName":"A"}`))
+
+	if report := r.Verify("test-1"); len(report.Unmet) != 0 {
+		t.Errorf("expected met expectation after a matching call, got: %+v", report.Unmet)
+	}
+}
@@ -0,0 +1,66 @@
+package expectation
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Report is the result of verifying expectations.
+type Report struct {
+	// Whether all expectations are met and no unexpected calls were received.
+	Satisfied bool `json:"satisfied"`
+
+	// Number of verified expectations.
+	Expectations int `json:"expectations"`
+
+	// Expectations whose number of matching calls is not as required.
+	Unmet []UnmetExpectation `json:"unmet"`
+
+	// Calls matching no expectation.
+	Unexpected []Call `json:"unexpected"`
+
+	// Readable description of the unmet expectations and unexpected calls.
+	Diff string `json:"diff"`
+}
+
+// UnmetExpectation is an expectation whose number of matching calls is not as
+// required, along with calls having the expected namespace, method and path
+// which did not otherwise match.
+type UnmetExpectation struct {
+	Expectation *Expectation `json:"expectation"`
+	NearMisses  []Call       `json:"nearMisses"`
+}
+
+// diff returns a readable description of the unmet expectations and
+// unexpected calls, or an empty string if the report is satisfied.
+func (r *Report) diff() string {
+	if r.Satisfied {
+		return ""
+	}
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%d of %d expectations unmet, %d unexpected calls\n", len(r.Unmet), r.Expectations, len(r.Unexpected))
+
+	for _, unmet := range r.Unmet {
+		fmt.Fprintf(&builder, "\n- expected %s, got %d (%s)\n", unmet.Expectation, unmet.Expectation.Count, unmet.Expectation.ID)
+
+		for _, nearMiss := range unmet.NearMisses {
+			fmt.Fprintf(&builder, "    non-matching %s:\n", nearMiss)
+
+			for _, mismatch := range nearMiss.Mismatches {
+				fmt.Fprintf(&builder, "      %s\n", mismatch)
+			}
+		}
+	}
+
+	if len(r.Unexpected) > 0 {
+		builder.WriteString("\n")
+	}
+
+	for _, call := range r.Unexpected {
+		fmt.Fprintf(&builder, "+ unexpected %s\n", call)
+	}
+
+	return builder.String()
+}
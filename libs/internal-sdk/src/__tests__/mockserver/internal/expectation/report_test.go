@@ -0,0 +1,61 @@
+package expectation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportDiff(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	exactly := 2
+
+	report := &Report{
+		Expectations: 3,
+		Unmet: []UnmetExpectation{
+			{
+				Expectation: &Expectation{ID: "exp_1", Method: "POST", Path: "/v1/events/trigger", Times: Times{Exactly: &exactly}, Count: 1},
+				NearMisses: []Call{
+					{Method: "POST", Path: "/v1/events/trigger", Time: at, Mismatches: []string{`body $.name: expected "welcome", got "other"`, "expected header X-Request"}},
+				},
+			},
+			{
+				Expectation: &Expectation{ID: "exp_2", Namespace: "test-1", Method: "GET", Path: "/v1/environments"},
+				NearMisses:  []Call{},
+			},
+		},
+		Unexpected: []Call{
+			{Method: "DELETE", Path: "/v2/subscribers/alice", Namespace: "test-1", Time: at},
+		},
+	}
+
+	expected := `2 of 3 expectations unmet, 1 unexpected calls
+
+- expected POST /v1/events/trigger exactly 2 times, got 1 (exp_1)
+    non-matching POST /v1/events/trigger at 2026-01-02T03:04:05Z:
+      body $.name: expected "welcome", got "other"
+      expected header X-Request
+
+- expected GET /v1/environments in namespace test-1 at least 1 time, got 0 (exp_2)
+
++ unexpected DELETE /v2/subscribers/alice in namespace test-1 at 2026-01-02T03:04:05Z
+`
+
+	if got := report.diff(); got != expected {
+		t.Errorf("expected diff:\n%s\ngot:\n%s", expected, got)
+	}
+
+	report.Unmet = nil
+
+	expected = `0 of 3 expectations unmet, 1 unexpected calls
+
++ unexpected DELETE /v2/subscribers/alice in namespace test-1 at 2026-01-02T03:04:05Z
+`
+
+	if got := report.diff(); got != expected {
+		t.Errorf("expected diff without unmet expectations:\n%s\ngot:\n%s", expected, got)
+	}
+
+	if got := (&Report{Satisfied: true, Expectations: 1}).diff(); got != "" {
+		t.Errorf("expected empty diff of a satisfied report, got: %q", got)
+	}
+}
@@ -0,0 +1,320 @@
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// HAR specification version.
+	harVersion = "1.2"
+
+	// HAR creator name and version.
+	harCreatorName    = "mockserver"
+	harCreatorVersion = "1.0"
+)
+
+// HAR is a HTTP Archive (HAR) 1.2 document.
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+// HARLog is the root of a HAR document.
+type HARLog struct {
+	Version string     `json:"version"`
+	Creator HARCreator `json:"creator"`
+	Entries []HAREntry `json:"entries"`
+}
+
+// HARCreator identifies the application which created a HAR document.
+type HARCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// HAREntry is a single request and response pair.
+type HAREntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         HARRequest  `json:"request"`
+	Response        HARResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         HARTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+// HARRequest is the request of a HAREntry.
+type HARRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	QueryString []HARNameValue `json:"queryString"`
+	PostData    *HARPostData   `json:"postData,omitempty"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARResponse is the response of a HAREntry.
+type HARResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []HARNameValue `json:"cookies"`
+	Headers     []HARNameValue `json:"headers"`
+	Content     HARContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+// HARNameValue is a header, cookie or query string parameter.
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HARPostData is the body of a HARRequest. HAR post data has no encoding
+// field, so bodies which are not valid UTF-8 are base64 encoded and marked by
+// the custom _encoding field.
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"_encoding,omitempty"`
+}
+
+// HARContent is the body of a HARResponse. Bodies which are not valid UTF-8
+// are base64 encoded.
+type HARContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// HARTimings are the durations in milliseconds of a HAREntry. Only the wait
+// time, from receiving the request to completing the response, is known.
+type HARTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// NewHAR converts all stored calls of the given operations into a HAR
+// document, with entries ordered by when the server received the request.
+// Calls evicted while converting are skipped.
+func NewHAR(operations []*OASOperation) (*HAR, error) {
+	result := &HAR{
+		Log: HARLog{
+			Version: harVersion,
+			Creator: HARCreator{
+				Name:    harCreatorName,
+				Version: harCreatorVersion,
+			},
+			Entries: []HAREntry{},
+		},
+	}
+
+	type sequencedEntry struct {
+		entry    HAREntry
+		sequence int64
+	}
+
+	var entries []sequencedEntry
+
+	for _, operation := range operations {
+		for _, call := range operation.Calls() {
+			entry, err := newHAREntry(call)
+
+			if IsEvicted(err) {
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error converting operation %s call %d to HAR: %w", operation.Id(), call.Call(), err)
+			}
+
+			sequence, err := call.Sequence()
+
+			if IsEvicted(err) {
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error converting operation %s call %d to HAR: %w", operation.Id(), call.Call(), err)
+			}
+
+			entries = append(entries, sequencedEntry{entry: entry, sequence: sequence})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].sequence < entries[j].sequence
+	})
+
+	for _, entry := range entries {
+		result.Log.Entries = append(result.Log.Entries, entry.entry)
+	}
+
+	return result, nil
+}
+
+// newHAREntry converts a single operation call into a HAR entry.
+func newHAREntry(call *OASOperationCall) (HAREntry, error) {
+	rawRequest, err := call.RawRequest()
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	// Parse a fresh request, as the body of a cached request may already be
+	// consumed.
+	req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(rawRequest)))
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	reqBody, err := io.ReadAll(req.Body)
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	rawResponse, err := call.RawResponse()
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(rawResponse)), req)
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	start, err := call.RequestTime()
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	end, err := call.ResponseTime()
+	if err != nil {
+		return HAREntry{}, err
+	}
+
+	wait := float64(max(end.Sub(start), 0).Microseconds()) / 1000
+
+	result := HAREntry{
+		StartedDateTime: start.UTC().Format(time.RFC3339Nano),
+		Time:            wait,
+		Request: HARRequest{
+			Method:      req.Method,
+			URL:         "http://" + req.Host + req.URL.RequestURI(),
+			HTTPVersion: req.Proto,
+			Cookies:     harCookies(req.Cookies()),
+			Headers:     harHeaders(req.Header),
+			QueryString: []HARNameValue{},
+			HeadersSize: -1,
+			BodySize:    int64(len(reqBody)),
+		},
+		Response: HARResponse{
+			Status:      resp.StatusCode,
+			StatusText:  http.StatusText(resp.StatusCode),
+			HTTPVersion: resp.Proto,
+			Cookies:     harCookies(resp.Cookies()),
+			Headers:     harHeaders(resp.Header),
+			Content:     harContent(resp.Header.Get("Content-Type"), respBody),
+			HeadersSize: -1,
+			BodySize:    int64(len(respBody)),
+		},
+		Timings: HARTimings{
+			Wait: wait,
+		},
+		Comment: fmt.Sprintf("operation %s call %d", call.Operation().Id(), call.Call()),
+	}
+
+	for key, values := range req.URL.Query() {
+		for _, value := range values {
+			result.Request.QueryString = append(result.Request.QueryString, HARNameValue{Name: key, Value: value})
+		}
+	}
+
+	sort.SliceStable(result.Request.QueryString, func(i, j int) bool {
+		return result.Request.QueryString[i].Name < result.Request.QueryString[j].Name
+	})
+
+	if len(reqBody) > 0 {
+		content := harContent(req.Header.Get("Content-Type"), reqBody)
+
+		result.Request.PostData = &HARPostData{
+			MimeType: content.MimeType,
+			Text:     content.Text,
+			Encoding: content.Encoding,
+		}
+	}
+
+	if location := resp.Header.Get("Location"); location != "" {
+		result.Response.RedirectURL = location
+	}
+
+	return result, nil
+}
+
+// harContent converts a body into HAR content, base64 encoding bodies which
+// are not valid UTF-8.
+func harContent(mimeType string, body []byte) HARContent {
+	result := HARContent{
+		Size:     int64(len(body)),
+		MimeType: mimeType,
+	}
+
+	if len(body) == 0 {
+		return result
+	}
+
+	if utf8.Valid(body) {
+		result.Text = string(body)
+	} else {
+		result.Text = base64.StdEncoding.EncodeToString(body)
+		result.Encoding = "base64"
+	}
+
+	return result
+}
+
+// harCookies converts cookies into HAR name and value pairs.
+func harCookies(cookies []*http.Cookie) []HARNameValue {
+	result := make([]HARNameValue, 0, len(cookies))
+
+	for _, cookie := range cookies {
+		result = append(result, HARNameValue{Name: cookie.Name, Value: cookie.Value})
+	}
+
+	return result
+}
+
+// harHeaders converts headers into HAR name and value pairs, sorted by name.
+func harHeaders(header http.Header) []HARNameValue {
+	result := make([]HARNameValue, 0, len(header))
+
+	for key, values := range header {
+		for _, value := range values {
+			result = append(result, HARNameValue{Name: key, Value: value})
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
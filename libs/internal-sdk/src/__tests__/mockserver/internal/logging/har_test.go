@@ -0,0 +1,126 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewHAR(t *testing.T) {
+	d, err := NewHTTPFileDirectory(t.TempDir())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	binary := []byte{0xff, 0xfe, 0x00, 0x01}
+
+	calls := []struct {
+		operationId string
+		target      string
+		body        []byte
+		respBody    []byte
+	}{
+		{operationId: "b", target: "/b?z=2&a=1&a=3", body: []byte(`{"name":"b"}`), respBody: []byte(`{"ok":true}`)},
+		{operationId: "a", target: "/a", body: binary, respBody: binary},
+		{operationId: "b", target: "/b", respBody: nil},
+	}
+
+	for _, call := range calls {
+		handler := d.HandlerFunc(call.operationId, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Header().Set("X-Call", call.target)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(call.respBody)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, call.target, bytes.NewReader(call.body))
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.Header.Set("X-Request", "value")
+
+		// Servers receive the header from clients, which the raw request
+		// needs to frame the body.
+		req.Header.Set("Content-Length", strconv.Itoa(len(call.body)))
+
+		handler(httptest.NewRecorder(), req)
+	}
+
+	operations, err := d.Operations()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	har, err := NewHAR(operations)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	entries := har.Log.Entries
+
+	if len(entries) != len(calls) {
+		t.Fatalf("expected %d entries, got: %d", len(calls), len(entries))
+	}
+
+	// Entries are ordered by when the requests were received, across
+	// operations.
+	for i, call := range calls {
+		if got := entries[i].Request.URL; got != "http://example.com"+call.target {
+			t.Errorf("expected entry %d URL %s, got: %s", i, call.target, got)
+		}
+	}
+
+	expectedQuery := []HARNameValue{{Name: "a", Value: "1"}, {Name: "a", Value: "3"}, {Name: "z", Value: "2"}}
+
+	if got := entries[0].Request.QueryString; !slices.Equal(got, expectedQuery) {
+		t.Errorf("expected query string %v, got: %v", expectedQuery, got)
+	}
+
+	if got := entries[2].Request.QueryString; got == nil || len(got) != 0 {
+		t.Errorf("expected empty query string, got: %#v", got)
+	}
+
+	if !slices.Contains(entries[0].Request.Headers, HARNameValue{Name: "X-Request", Value: "value"}) {
+		t.Errorf("expected X-Request request header, got: %v", entries[0].Request.Headers)
+	}
+
+	if !slices.Contains(entries[0].Response.Headers, HARNameValue{Name: "X-Call", Value: calls[0].target}) {
+		t.Errorf("expected X-Call response header, got: %v", entries[0].Response.Headers)
+	}
+
+	if !slices.IsSortedFunc(entries[0].Request.Headers, func(a, b HARNameValue) int { return strings.Compare(a.Name, b.Name) }) {
+		t.Errorf("expected request headers sorted by name, got: %v", entries[0].Request.Headers)
+	}
+
+	if got := entries[0].Request.PostData; got == nil || got.Text != `{"name":"b"}` || got.Encoding != "" {
+		t.Errorf("expected text post data, got: %+v", got)
+	}
+
+	if got := entries[0].Response.Content; got.Text != `{"ok":true}` || got.Encoding != "" {
+		t.Errorf("expected text response content, got: %+v", got)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(binary)
+
+	if got := entries[1].Request.PostData; got == nil || got.Text != encoded || got.Encoding != "base64" {
+		t.Errorf("expected base64 post data %s, got: %+v", encoded, got)
+	}
+
+	if got := entries[1].Response.Content; got.Text != encoded || got.Encoding != "base64" || got.Size != int64(len(binary)) {
+		t.Errorf("expected base64 response content %s, got: %+v", encoded, got)
+	}
+
+	if got := entries[2].Request.PostData; got != nil {
+		t.Errorf("expected no post data, got: %+v", got)
+	}
+
+	if got := entries[2].Response.Content; got.Text != "" || got.Encoding != "" || got.Size != 0 {
+		t.Errorf("expected empty response content, got: %+v", got)
+	}
+}
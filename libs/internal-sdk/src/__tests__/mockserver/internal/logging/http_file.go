@@ -1,12 +1,12 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package logging
 
 import (
 	"bufio"
 	"bytes"
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
 	"net/http"
@@ -14,37 +14,101 @@ import (
 	"net/http/httputil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
 const (
 	// DefaultHTTPFileDirectory is the default directory used for raw HTTP
 	// request and response files.
 	DefaultHTTPFileDirectory = "_debug"
+
+	// DefaultCoverageFilename is the file name of the operation coverage
+	// report, which is written into the directory by default.
+	DefaultCoverageFilename = "coverage.json"
+
+	// File name suffix of gzip compressed raw HTTP request and response files.
+	gzipFileSuffix = ".gz"
 )
 
+// ErrNotStored is returned when reading a call without files in the
+// directory, such as a call evicted by a limit.
+var ErrNotStored = errors.New("not stored")
+
+// IsEvicted returns whether err is caused by reading a call whose files were
+// evicted, which may happen between listing calls and reading them.
+func IsEvicted(err error) bool {
+	return errors.Is(err, ErrNotStored) || errors.Is(err, fs.ErrNotExist)
+}
+
 // HTTPFileDirectory is the directory where raw HTTP request and response files
 // are written.
 type HTTPFileDirectory struct {
 	// Filesystem at path.
 	filesystem fs.FS
 
+	// Whether new files are gzip compressed.
+	gzip bool
+
+	// Maximum number of calls kept per operation. Zero is unlimited.
+	maxCalls int64
+
+	// Maximum total size in bytes of all kept files. Zero is unlimited.
+	maxSize int64
+
 	// Mapping of operations to call count. Used to sequentially increment file
 	// names and return current count.
 	operationCalls map[string]int64
 
-	// Mutex to protect operationCalls.
+	// Mutex to protect operationCalls, sequence, storedCalls,
+	// storedCallsByName, and storedSize.
 	operationCallsMutex *sync.RWMutex
 
 	// Absolute path to directory.
 	path string
+
+	// Sequence number of the most recently received call across all
+	// operations.
+	sequence int64
+
+	// Calls with files in the directory, oldest first. Used to evict files
+	// when a limit is exceeded.
+	storedCalls []*storedHTTPFileCall
+
+	// Calls with files in the directory by operation call file name prefix.
+	storedCallsByName map[string]*storedHTTPFileCall
+
+	// Total size in bytes of all files in storedCalls.
+	storedSize int64
+}
+
+// storedHTTPFileCall is a single operation call with files in the directory.
+type storedHTTPFileCall struct {
+	// Call number of the operation.
+	call int64
+
+	// Operation identifier, sanitized for file names.
+	operationId string
+
+	// Order in which the call was received, across all operations.
+	sequence int64
+
+	// Combined size in bytes of the request and response files.
+	size int64
+
+	// When the server received the request and completed the response. For
+	// files of a previous run, these are the file modification times.
+	requestTime  time.Time
+	responseTime time.Time
 }
 
 // NewHTTPFileDirectory will create a HTTPFileDirectory which exists and is a
-// directory or will return an error.
-func NewHTTPFileDirectory(explicitPath string) (*HTTPFileDirectory, error) {
+// directory or will return an error. Files already in the directory, such as
+// those of a previous run, are kept and subject to any configured limits.
+func NewHTTPFileDirectory(explicitPath string, opts ...HTTPFileDirectoryOption) (*HTTPFileDirectory, error) {
 	path, err := filepath.Abs(DefaultHTTPFileDirectory)
 	if err != nil {
 		return nil, fmt.Errorf("error getting absolute path of HTTP file directory (%s): %w", DefaultHTTPFileDirectory, err)
@@ -64,6 +128,11 @@ func NewHTTPFileDirectory(explicitPath string) (*HTTPFileDirectory, error) {
 		operationCalls:      make(map[string]int64),
 		operationCallsMutex: new(sync.RWMutex),
 		path:                path,
+		storedCallsByName:   make(map[string]*storedHTTPFileCall),
+	}
+
+	for _, opt := range opts {
+		opt(result)
 	}
 
 	fileInfo, err := os.Stat(path)
@@ -85,11 +154,25 @@ func NewHTTPFileDirectory(explicitPath string) (*HTTPFileDirectory, error) {
 		return nil, fmt.Errorf("error using HTTP file directory (%s): not a directory", path)
 	}
 
+	err = result.loadStoredCalls()
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
-// Clean will remove all files from HTTPFileDirectory.
+// Clean will remove all raw HTTP request and response files and the default
+// coverage report from HTTPFileDirectory and reset all operation call counts.
+// Other files are kept, as the directory may be shared.
 func (d *HTTPFileDirectory) Clean() error {
+	d.operationCallsMutex.Lock()
+	d.operationCalls = make(map[string]int64)
+	d.storedCalls = nil
+	d.storedCallsByName = make(map[string]*storedHTTPFileCall)
+	d.storedSize = 0
+	d.operationCallsMutex.Unlock()
+
 	walkDirFunc := func(path string, entry fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("error walking %s: %w", d.path, err)
@@ -103,6 +186,10 @@ func (d *HTTPFileDirectory) Clean() error {
 			return fs.SkipDir
 		}
 
+		if _, _, ok := parseOperationCallFilename(path); !ok && path != DefaultCoverageFilename {
+			return nil
+		}
+
 		absPath := filepath.Join(d.path, path)
 
 		err = os.Remove(absPath)
@@ -118,10 +205,18 @@ func (d *HTTPFileDirectory) Clean() error {
 
 // HandlerFunc is a HTTP handler that automatically writes the raw HTTP
 // request and response to {path}/{operationId}_{call}_request and
-// {path}/{operationId}_{call}_response files respectively.
+// {path}/{operationId}_{call}_response files respectively. Files are suffixed
+// with .gz when gzip compression is enabled.
 func (d *HTTPFileDirectory) HandlerFunc(operationId string, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		call := d.nextOperationCall(operationId)
+		storedCall := &storedHTTPFileCall{
+			operationId: sanitizeOperationIdForFilename(operationId),
+			requestTime: time.Now(),
+		}
+		storedCall.call, storedCall.sequence = d.nextOperationCall(operationId)
+		call := storedCall.call
+
+		setHTTPStreamOperation(req.Context(), operationId, call)
 
 		dump, err := httputil.DumpRequest(req, true)
 		if err != nil {
@@ -129,83 +224,105 @@ func (d *HTTPFileDirectory) HandlerFunc(operationId string, next http.HandlerFun
 		}
 
 		if len(dump) > 0 {
-			requestPath := filepath.Join(d.path, d.operationCallRequestFilename(operationId, call))
-
-			err = os.WriteFile(requestPath, dump, 0o644)
-			if err != nil {
-				log.Printf("error writing HTTP request file (%s): %s", requestPath, err)
-			}
+			storedCall.size += d.writeFile(d.operationCallRequestFilename(operationId, call), dump)
 		}
 
 		recorder := httptest.NewRecorder()
 
 		next(recorder, req)
 
+		storedCall.responseTime = time.Now()
+
 		dump, err = httputil.DumpResponse(recorder.Result(), true)
 		if err != nil {
 			log.Printf("error dumping HTTP response: %s", err)
 		}
 
 		if len(dump) > 0 {
-			responsePath := filepath.Join(d.path, d.operationCallResponseFilename(operationId, call))
-			err = os.WriteFile(responsePath, dump, 0o644)
-			if err != nil {
-				log.Printf("error writing HTTP response file (%s): %s", responsePath, err)
-			}
+			storedCall.size += d.writeFile(d.operationCallResponseFilename(operationId, call), dump)
 		}
 
+		d.storeCall(storedCall)
+
 		recorderToWriter(recorder, w)
 	}
 }
 
-// Operation will return a new OASOperation from HTTPFileDirectory.
+// Operation will return a new OASOperation from HTTPFileDirectory. Its method
+// and path are those of the oldest call which is still stored.
 func (d *HTTPFileDirectory) Operation(operationId string) (*OASOperation, error) {
-	request, err := d.Request(operationId, 1)
-	if err != nil {
-		return nil, err
+	for _, call := range d.OperationStoredCalls(operationId) {
+		request, err := d.Request(operationId, call)
+
+		if IsEvicted(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result := NewOASOperation(d, operationId, request.Method, request.URL.Path)
+
+		return result, nil
 	}
 
-	result := NewOASOperation(d, operationId, request.Method, request.URL.Path)
+	return nil, fmt.Errorf("error reading HTTP files for operation %s: no calls %w", operationId, ErrNotStored)
+}
 
-	return result, nil
+// Path returns the absolute path of the directory.
+func (d *HTTPFileDirectory) Path() string {
+	return d.path
 }
 
-// Operations will return all detected OASOperation from HTTPFileDirectory.
+// Operations will return all detected OASOperation from HTTPFileDirectory,
+// sorted by operation identifier. Operations whose calls are all evicted
+// while reading are skipped.
 func (d *HTTPFileDirectory) Operations() ([]*OASOperation, error) {
-	var result []*OASOperation
+	d.operationCallsMutex.RLock()
 
-	walkDirFunc := func(path string, entry fs.DirEntry, err error) error {
-		if err != nil {
-			return fmt.Errorf("error walking %s: %w", d.path, err)
-		}
+	operationIds := make([]string, 0, len(d.operationCalls))
+	seen := make(map[string]bool, len(d.operationCalls))
 
-		if !strings.HasSuffix(path, "_1_request") {
-			return nil
+	for _, storedCall := range d.storedCalls {
+		if seen[storedCall.operationId] {
+			continue
 		}
 
-		operationId := strings.TrimSuffix(path, "_1_request")
+		seen[storedCall.operationId] = true
+		operationIds = append(operationIds, storedCall.operationId)
+	}
+
+	d.operationCallsMutex.RUnlock()
+
+	sort.Strings(operationIds)
+
+	result := make([]*OASOperation, 0, len(operationIds))
+
+	for _, operationId := range operationIds {
 		operation, err := d.Operation(operationId)
+
+		if IsEvicted(err) {
+			continue
+		}
+
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		result = append(result, operation)
-
-		return nil
 	}
 
-	err := fs.WalkDir(d.filesystem, ".", walkDirFunc)
-
-	return result, err
+	return result, nil
 }
 
 // OperationCallCount will return the number of detected calls for an
-// OASOperation.
+// OASOperation, including calls whose files were since evicted.
 func (d *HTTPFileDirectory) OperationCallCount(operationId string) int64 {
 	d.operationCallsMutex.RLock()
 	defer d.operationCallsMutex.RUnlock()
 
-	result, ok := d.operationCalls[operationId]
+	result, ok := d.operationCalls[sanitizeOperationIdForFilename(operationId)]
 
 	if ok {
 		return result
@@ -214,11 +331,32 @@ func (d *HTTPFileDirectory) OperationCallCount(operationId string) int64 {
 	return 0
 }
 
+// OperationStoredCalls will return the call numbers, in ascending order, of
+// an OASOperation which still have files in the directory.
+func (d *HTTPFileDirectory) OperationStoredCalls(operationId string) []int64 {
+	d.operationCallsMutex.RLock()
+	defer d.operationCallsMutex.RUnlock()
+
+	operationId = sanitizeOperationIdForFilename(operationId)
+
+	var result []int64
+
+	for _, storedCall := range d.storedCalls {
+		if storedCall.operationId == operationId {
+			result = append(result, storedCall.call)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
+
 // RawRequest returns the raw HTTP request contents as dumped by
 // [httputil.DumpRequest].
 func (d *HTTPFileDirectory) RawRequest(operationId string, call int64) ([]byte, error) {
 	filename := d.operationCallRequestFilename(operationId, call)
-	file, err := fs.ReadFile(d.filesystem, filename)
+	file, err := d.readFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("error reading HTTP request file (%s): %w", filename, err)
 	}
@@ -230,7 +368,7 @@ func (d *HTTPFileDirectory) RawRequest(operationId string, call int64) ([]byte,
 // [httputil.DumpResponse].
 func (d *HTTPFileDirectory) RawResponse(operationId string, call int64) ([]byte, error) {
 	filename := d.operationCallResponseFilename(operationId, call)
-	file, err := fs.ReadFile(d.filesystem, d.operationCallResponseFilename(operationId, call))
+	file, err := d.readFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("error reading HTTP response file (%s): %w", filename, err)
 	}
@@ -238,6 +376,37 @@ func (d *HTTPFileDirectory) RawResponse(operationId string, call int64) ([]byte,
 	return file, nil
 }
 
+// RequestTime returns when the server received the HTTP request.
+func (d *HTTPFileDirectory) RequestTime(operationId string, call int64) (time.Time, error) {
+	storedCall, err := d.storedCall(operationId, call)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return storedCall.requestTime, nil
+}
+
+// ResponseTime returns when the server completed the HTTP response.
+func (d *HTTPFileDirectory) ResponseTime(operationId string, call int64) (time.Time, error) {
+	storedCall, err := d.storedCall(operationId, call)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return storedCall.responseTime, nil
+}
+
+// Sequence returns the order in which the server received the call, across
+// all operations.
+func (d *HTTPFileDirectory) Sequence(operationId string, call int64) (int64, error) {
+	storedCall, err := d.storedCall(operationId, call)
+	if err != nil {
+		return 0, err
+	}
+
+	return storedCall.sequence, nil
+}
+
 // Request returns the parsed HTTP request contents.
 func (d *HTTPFileDirectory) Request(operationId string, call int64) (*http.Request, error) {
 	rawRequest, err := d.RawRequest(operationId, call)
@@ -268,11 +437,146 @@ func (d *HTTPFileDirectory) Response(operationId string, call int64) (*http.Resp
 	return result, nil
 }
 
-// nextOperationCall returns the incremented call number for an operation.
-func (d *HTTPFileDirectory) nextOperationCall(operationId string) int64 {
+// evict removes the files of the oldest calls until all limits are met. The
+// most recent call is always kept. Must be called with operationCallsMutex
+// locked.
+func (d *HTTPFileDirectory) evict() {
+	if d.maxCalls > 0 {
+		counts := make(map[string]int64)
+
+		for _, storedCall := range d.storedCalls {
+			counts[storedCall.operationId]++
+		}
+
+		kept := d.storedCalls[:0]
+
+		for _, storedCall := range d.storedCalls {
+			if counts[storedCall.operationId] > d.maxCalls {
+				counts[storedCall.operationId]--
+				d.removeCallFiles(storedCall)
+
+				continue
+			}
+
+			kept = append(kept, storedCall)
+		}
+
+		d.storedCalls = kept
+	}
+
+	for d.maxSize > 0 && d.storedSize > d.maxSize && len(d.storedCalls) > 1 {
+		d.removeCallFiles(d.storedCalls[0])
+		d.storedCalls = d.storedCalls[1:]
+	}
+}
+
+// loadStoredCalls indexes existing files in the directory, oldest first, so
+// call numbering continues after them, then applies any limits.
+func (d *HTTPFileDirectory) loadStoredCalls() error {
+	type fileCall struct {
+		storedHTTPFileCall
+		modTime time.Time
+	}
+
+	fileCalls := make(map[string]*fileCall)
+
+	walkDirFunc := func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error walking %s: %w", d.path, err)
+		}
+
+		if entry.IsDir() {
+			if path == "." {
+				return nil
+			}
+
+			return fs.SkipDir
+		}
+
+		operationId, call, ok := parseOperationCallFilename(path)
+
+		if !ok {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filepath.Join(d.path, path), err)
+		}
+
+		key := operationId + "_" + strconv.FormatInt(call, 10)
+		item, ok := fileCalls[key]
+
+		if !ok {
+			item = &fileCall{storedHTTPFileCall: storedHTTPFileCall{call: call, operationId: operationId}}
+			fileCalls[key] = item
+		}
+
+		item.size += info.Size()
+
+		if strings.HasSuffix(strings.TrimSuffix(path, gzipFileSuffix), "_request") {
+			item.requestTime = info.ModTime()
+		} else {
+			item.responseTime = info.ModTime()
+		}
+
+		if info.ModTime().After(item.modTime) {
+			item.modTime = info.ModTime()
+		}
+
+		return nil
+	}
+
+	err := fs.WalkDir(d.filesystem, ".", walkDirFunc)
+	if err != nil {
+		return err
+	}
+
+	sorted := make([]*fileCall, 0, len(fileCalls))
+
+	for _, item := range fileCalls {
+		sorted = append(sorted, item)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].modTime.Equal(sorted[j].modTime) {
+			return sorted[i].modTime.Before(sorted[j].modTime)
+		}
+
+		if sorted[i].operationId != sorted[j].operationId {
+			return sorted[i].operationId < sorted[j].operationId
+		}
+
+		return sorted[i].call < sorted[j].call
+	})
+
 	d.operationCallsMutex.Lock()
 	defer d.operationCallsMutex.Unlock()
 
+	for _, item := range sorted {
+		d.sequence++
+		item.sequence = d.sequence
+
+		storedCall := item.storedHTTPFileCall
+		d.storedCalls = append(d.storedCalls, &storedCall)
+		d.storedCallsByName[storedCall.name()] = &storedCall
+		d.storedSize += item.size
+		d.operationCalls[item.operationId] = max(d.operationCalls[item.operationId], item.call)
+	}
+
+	d.evict()
+
+	return nil
+}
+
+// nextOperationCall returns the incremented call number for an operation and
+// the incremented sequence number across all operations.
+func (d *HTTPFileDirectory) nextOperationCall(operationId string) (int64, int64) {
+	d.operationCallsMutex.Lock()
+	defer d.operationCallsMutex.Unlock()
+
+	operationId = sanitizeOperationIdForFilename(operationId)
+
 	var result int64
 
 	priorCalls, ok := d.operationCalls[operationId]
@@ -285,8 +589,9 @@ func (d *HTTPFileDirectory) nextOperationCall(operationId string) int64 {
 	}
 
 	d.operationCalls[operationId] = result
+	d.sequence++
 
-	return result
+	return result, d.sequence
 }
 
 // operationCallRequestFilename returns the raw HTTP request file name for the
@@ -301,6 +606,141 @@ func (d *HTTPFileDirectory) operationCallResponseFilename(operationId string, ca
 	return sanitizeOperationIdForFilename(operationId) + "_" + strconv.FormatInt(call, 10) + "_response"
 }
 
+// readFile returns the contents of the named file, falling back to a gzip
+// compressed variant of it.
+func (d *HTTPFileDirectory) readFile(filename string) ([]byte, error) {
+	file, err := fs.ReadFile(d.filesystem, filename)
+
+	if !errors.Is(err, fs.ErrNotExist) {
+		return file, err
+	}
+
+	compressed, gzipErr := fs.ReadFile(d.filesystem, filename+gzipFileSuffix)
+	if gzipErr != nil {
+		// Report the uncompressed file error for clarity.
+		return nil, err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	return io.ReadAll(reader)
+}
+
+// removeCallFiles removes the request and response files of a call, removes it
+// from storedCallsByName and subtracts their size. Must be called with
+// operationCallsMutex locked.
+func (d *HTTPFileDirectory) removeCallFiles(storedCall *storedHTTPFileCall) {
+	for _, filename := range []string{
+		d.operationCallRequestFilename(storedCall.operationId, storedCall.call),
+		d.operationCallResponseFilename(storedCall.operationId, storedCall.call),
+	} {
+		for _, name := range []string{filename, filename + gzipFileSuffix} {
+			absPath := filepath.Join(d.path, name)
+
+			err := os.Remove(absPath)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.Printf("error removing HTTP file (%s): %s", absPath, err)
+			}
+		}
+	}
+
+	delete(d.storedCallsByName, storedCall.name())
+	d.storedSize -= storedCall.size
+}
+
+// storeCall records the files of a completed call, then applies any limits.
+func (d *HTTPFileDirectory) storeCall(storedCall *storedHTTPFileCall) {
+	d.operationCallsMutex.Lock()
+	defer d.operationCallsMutex.Unlock()
+
+	d.storedCalls = append(d.storedCalls, storedCall)
+	d.storedCallsByName[storedCall.name()] = storedCall
+	d.storedSize += storedCall.size
+
+	d.evict()
+}
+
+// storedCall returns the stored call of an operation, or an error wrapping
+// ErrNotStored if it has no files in the directory.
+func (d *HTTPFileDirectory) storedCall(operationId string, call int64) (*storedHTTPFileCall, error) {
+	d.operationCallsMutex.RLock()
+	defer d.operationCallsMutex.RUnlock()
+
+	result, ok := d.storedCallsByName[sanitizeOperationIdForFilename(operationId)+"_"+strconv.FormatInt(call, 10)]
+
+	if !ok {
+		return nil, fmt.Errorf("error reading HTTP files for operation %s call %d: %w", operationId, call, ErrNotStored)
+	}
+
+	return result, nil
+}
+
+// name returns the operation call file name prefix, such as
+// {operationId}_{call}.
+func (c *storedHTTPFileCall) name() string {
+	return c.operationId + "_" + strconv.FormatInt(c.call, 10)
+}
+
+// writeFile writes the named file, gzip compressed if enabled, and returns
+// the number of bytes written.
+func (d *HTTPFileDirectory) writeFile(filename string, contents []byte) int64 {
+	if d.gzip {
+		var buf bytes.Buffer
+
+		writer := gzip.NewWriter(&buf)
+		_, _ = writer.Write(contents)
+		_ = writer.Close()
+
+		filename += gzipFileSuffix
+		contents = buf.Bytes()
+	}
+
+	path := filepath.Join(d.path, filename)
+
+	err := os.WriteFile(path, contents, 0o644)
+	if err != nil {
+		log.Printf("error writing HTTP file (%s): %s", path, err)
+
+		return 0
+	}
+
+	return int64(len(contents))
+}
+
+// parseOperationCallFilename returns the sanitized operation identifier and
+// call number of a raw HTTP request or response file name.
+func parseOperationCallFilename(filename string) (string, int64, bool) {
+	name := strings.TrimSuffix(filename, gzipFileSuffix)
+
+	switch {
+	case strings.HasSuffix(name, "_request"):
+		name = strings.TrimSuffix(name, "_request")
+	case strings.HasSuffix(name, "_response"):
+		name = strings.TrimSuffix(name, "_response")
+	default:
+		return "", 0, false
+	}
+
+	separator := strings.LastIndex(name, "_")
+
+	if separator <= 0 {
+		return "", 0, false
+	}
+
+	call, err := strconv.ParseInt(name[separator+1:], 10, 64)
+
+	if err != nil || call < 1 {
+		return "", 0, false
+	}
+
+	return name[:separator], call, true
+}
+
 func sanitizeOperationIdForFilename(operationId string) string {
 	operationId = strings.ReplaceAll(operationId, "{", "_")
 	operationId = strings.ReplaceAll(operationId, "}", "_")
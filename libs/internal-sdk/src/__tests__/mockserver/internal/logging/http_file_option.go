@@ -0,0 +1,30 @@
+package logging
+
+// HTTPFileDirectoryOption is a function which modifies the HTTPFileDirectory.
+type HTTPFileDirectoryOption func(*HTTPFileDirectory)
+
+// WithHTTPFileGzip enables gzip compression of new raw HTTP request and
+// response files, which are then suffixed with .gz. By default, files are not
+// compressed.
+func WithHTTPFileGzip(enabled bool) HTTPFileDirectoryOption {
+	return func(d *HTTPFileDirectory) {
+		d.gzip = enabled
+	}
+}
+
+// WithHTTPFileMaxCalls sets the maximum number of calls kept per operation.
+// Files of the oldest calls are removed first. By default, all calls are kept.
+func WithHTTPFileMaxCalls(maxCalls int64) HTTPFileDirectoryOption {
+	return func(d *HTTPFileDirectory) {
+		d.maxCalls = maxCalls
+	}
+}
+
+// WithHTTPFileMaxSize sets the maximum total size in bytes of all files kept.
+// Files of the oldest calls, across all operations, are removed first. By
+// default, the size is unlimited.
+func WithHTTPFileMaxSize(maxSize int64) HTTPFileDirectoryOption {
+	return func(d *HTTPFileDirectory) {
+		d.maxSize = maxSize
+	}
+}
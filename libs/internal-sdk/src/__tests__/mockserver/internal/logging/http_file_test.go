@@ -0,0 +1,290 @@
+package logging
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// serveCall sends a request with body through the directory handler of the
+// operation.
+func serveCall(t *testing.T, d *HTTPFileDirectory, operationId string, body string) {
+	t.Helper()
+
+	handler := d.HandlerFunc(operationId, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/"+operationId, strings.NewReader(body)))
+}
+
+// dirFilenames returns the sorted names of all files in the directory.
+func dirFilenames(t *testing.T, path string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(path)
+
+	if err != nil {
+		t.Fatalf("error reading directory: %s", err)
+	}
+
+	var result []string
+
+	for _, entry := range entries {
+		result = append(result, entry.Name())
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+func TestHTTPFileDirectoryClean(t *testing.T) {
+	path := t.TempDir()
+
+	for _, name := range []string{"important.txt", DefaultCoverageFilename, "op_1_request", "op_2_response.gz", "op_x_request"} {
+		if err := os.WriteFile(filepath.Join(path, name), []byte("contents"), 0o644); err != nil {
+			t.Fatalf("error writing %s: %s", name, err)
+		}
+	}
+
+	if err := os.Mkdir(filepath.Join(path, "nested"), 0o755); err != nil {
+		t.Fatalf("error making directory: %s", err)
+	}
+
+	d, err := NewHTTPFileDirectory(path)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if err := d.Clean(); err != nil {
+		t.Fatalf("unexpected error cleaning: %s", err)
+	}
+
+	expected := []string{"important.txt", "nested", "op_x_request"}
+
+	if got := dirFilenames(t, path); !slices.Equal(got, expected) {
+		t.Errorf("expected files %v, got: %v", expected, got)
+	}
+
+	if got := d.OperationCallCount("op"); got != 0 {
+		t.Errorf("expected call count 0, got: %d", got)
+	}
+}
+
+func TestHTTPFileDirectoryEvictMaxCalls(t *testing.T) {
+	path := t.TempDir()
+
+	d, err := NewHTTPFileDirectory(path, WithHTTPFileMaxCalls(2))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for range 3 {
+		serveCall(t, d, "a", "")
+	}
+
+	serveCall(t, d, "b", "")
+
+	if got, expected := d.OperationStoredCalls("a"), []int64{2, 3}; !slices.Equal(got, expected) {
+		t.Errorf("expected stored calls %v, got: %v", expected, got)
+	}
+
+	if got := d.OperationCallCount("a"); got != 3 {
+		t.Errorf("expected call count 3, got: %d", got)
+	}
+
+	expected := []string{"a_2_request", "a_2_response", "a_3_request", "a_3_response", "b_1_request", "b_1_response"}
+
+	if got := dirFilenames(t, path); !slices.Equal(got, expected) {
+		t.Errorf("expected files %v, got: %v", expected, got)
+	}
+}
+
+func TestHTTPFileDirectoryEvictConcurrentReads(t *testing.T) {
+	d, err := NewHTTPFileDirectory(t.TempDir(), WithHTTPFileMaxCalls(1))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	serveCall(t, d, "a", "{}")
+
+	var wg sync.WaitGroup
+
+	done := make(chan struct{})
+
+	for range 4 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					serveCall(t, d, "a", "{}")
+				}
+			}
+		}()
+	}
+
+	// Every read races the writers evicting the calls it just listed, which
+	// must be skipped instead of failing the read.
+	for range 200 {
+		operations, err := d.Operations()
+
+		if err != nil {
+			t.Fatalf("unexpected Operations error: %s", err)
+		}
+
+		for _, operation := range operations {
+			for _, call := range operation.Calls() {
+				_, err := NewJournalEntry(call)
+
+				if err != nil && !IsEvicted(err) {
+					t.Fatalf("unexpected NewJournalEntry error: %s", err)
+				}
+			}
+		}
+
+		_, err = NewHAR(operations)
+
+		if err != nil {
+			t.Fatalf("unexpected NewHAR error: %s", err)
+		}
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+func TestHTTPFileDirectoryEvictMaxSize(t *testing.T) {
+	path := t.TempDir()
+
+	d, err := NewHTTPFileDirectory(path, WithHTTPFileMaxSize(1))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	serveCall(t, d, "a", "first")
+	serveCall(t, d, "b", "second")
+
+	// The most recent call is kept even though it exceeds the limit.
+	if got := d.OperationStoredCalls("a"); len(got) != 0 {
+		t.Errorf("expected no stored calls of a, got: %v", got)
+	}
+
+	if got, expected := d.OperationStoredCalls("b"), []int64{1}; !slices.Equal(got, expected) {
+		t.Errorf("expected stored calls of b %v, got: %v", expected, got)
+	}
+
+	expected := []string{"b_1_request", "b_1_response"}
+
+	if got := dirFilenames(t, path); !slices.Equal(got, expected) {
+		t.Errorf("expected files %v, got: %v", expected, got)
+	}
+}
+
+func TestHTTPFileDirectoryLoadStoredCalls(t *testing.T) {
+	path := t.TempDir()
+
+	previous, err := NewHTTPFileDirectory(path, WithHTTPFileGzip(true))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	serveCall(t, previous, "a", "first")
+	serveCall(t, previous, "a", "second")
+	serveCall(t, previous, "a", "third")
+
+	d, err := NewHTTPFileDirectory(path, WithHTTPFileMaxCalls(2))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if got, expected := d.OperationStoredCalls("a"), []int64{2, 3}; !slices.Equal(got, expected) {
+		t.Errorf("expected stored calls %v, got: %v", expected, got)
+	}
+
+	request, err := d.Request("a", 3)
+
+	if err != nil {
+		t.Fatalf("unexpected error reading gzip compressed request: %s", err)
+	}
+
+	if request.Method != http.MethodPost {
+		t.Errorf("expected method %s, got: %s", http.MethodPost, request.Method)
+	}
+
+	serveCall(t, d, "a", "fourth")
+
+	if got, expected := d.OperationStoredCalls("a"), []int64{3, 4}; !slices.Equal(got, expected) {
+		t.Errorf("expected stored calls %v, got: %v", expected, got)
+	}
+}
+
+func TestHTTPFileDirectorySequence(t *testing.T) {
+	d, err := NewHTTPFileDirectory(t.TempDir())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	serveCall(t, d, "a", "")
+	serveCall(t, d, "b", "")
+	serveCall(t, d, "a", "")
+
+	calls := []struct {
+		operationId string
+		call        int64
+	}{
+		{operationId: "a", call: 1},
+		{operationId: "b", call: 1},
+		{operationId: "a", call: 2},
+	}
+
+	for i, call := range calls {
+		sequence, err := d.Sequence(call.operationId, call.call)
+
+		if err != nil {
+			t.Fatalf("unexpected error reading sequence: %s", err)
+		}
+
+		if expected := int64(i + 1); sequence != expected {
+			t.Errorf("expected %s call %d sequence %d, got: %d", call.operationId, call.call, expected, sequence)
+		}
+
+		requestTime, err := d.RequestTime(call.operationId, call.call)
+
+		if err != nil {
+			t.Fatalf("unexpected error reading request time: %s", err)
+		}
+
+		responseTime, err := d.ResponseTime(call.operationId, call.call)
+
+		if err != nil {
+			t.Fatalf("unexpected error reading response time: %s", err)
+		}
+
+		if requestTime.IsZero() || responseTime.Before(requestTime) {
+			t.Errorf("expected %s call %d response time %s after request time %s", call.operationId, call.call, responseTime, requestTime)
+		}
+	}
+
+	if _, err := d.Sequence("a", 3); err == nil {
+		t.Error("expected error reading sequence of unknown call")
+	}
+}
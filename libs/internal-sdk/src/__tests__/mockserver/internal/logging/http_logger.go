@@ -1,5 +1,3 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package logging
 
 import (
@@ -12,11 +10,18 @@ import (
 )
 
 // HTTPLoggerHandler wraps another [http.Handler] with logging output using the
-// provided logger.
-func HTTPLoggerHandler(logger *slog.Logger, next http.Handler) http.Handler {
+// provided logger. If stream is not nil, each request and response pair is
+// also published to it.
+func HTTPLoggerHandler(logger *slog.Logger, stream *HTTPStream, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		httpLogger := logger.WithGroup("http")
 		recorder := httptest.NewRecorder()
+
+		var streamCall *httpStreamCall
+
+		if stream.HasSubscribers() {
+			streamCall, req = stream.startCall(req)
+		}
 		reqAttr := slog.Group(
 			"request",
 			slog.String("method", req.Method),
@@ -35,6 +40,10 @@ func HTTPLoggerHandler(logger *slog.Logger, next http.Handler) http.Handler {
 
 			httpLogger.With(reqAttr).With(respAttr).Info("serving response")
 
+			if streamCall != nil {
+				stream.publish(streamCall, req, resp, recorder.Body.Bytes())
+			}
+
 			recorderToWriter(recorder, w)
 
 			return
@@ -75,6 +84,10 @@ func HTTPLoggerHandler(logger *slog.Logger, next http.Handler) http.Handler {
 		// Intentionally use simpler request log attributes
 		httpLogger.With(reqAttr).With(rawRespAttr).Debug("serving response")
 
+		if streamCall != nil {
+			stream.publish(streamCall, req, recorder.Result(), recorder.Body.Bytes())
+		}
+
 		recorderToWriter(recorder, w)
 	})
 }
@@ -0,0 +1,241 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"mockserver/internal/tracking"
+)
+
+const (
+	// DefaultHTTPStreamMaxBodySize is the default size in bytes at which
+	// request and response bodies of HTTPStreamEvent are truncated.
+	DefaultHTTPStreamMaxBodySize = 4096
+
+	// Number of events buffered per subscriber before further events are
+	// dropped for that subscriber.
+	httpStreamSubscriberBuffer = 256
+)
+
+// HTTPStream publishes every HTTP request and response pair handled by
+// [HTTPLoggerHandler] to its subscribers.
+type HTTPStream struct {
+	// Size in bytes at which bodies are truncated. Zero is unlimited.
+	maxBodySize int
+
+	// Mutex to protect nextID and subscribers.
+	mutex *sync.RWMutex
+
+	// Identifier of the next published event.
+	nextID int64
+
+	// Active subscribers.
+	subscribers map[*httpStreamSubscriber]struct{}
+}
+
+// HTTPStreamEvent is a single HTTP request and response pair.
+type HTTPStreamEvent struct {
+	// Sequential event identifier, starting at 1.
+	ID int64 `json:"id"`
+
+	// OAS operation identifier, if the request was handled by an operation.
+	OperationID string `json:"operationId,omitempty"`
+
+	// OAS operation call number, if the request was handled by an operation.
+	Call int64 `json:"call,omitempty"`
+
+	// Test namespace, from the tracking.TestInstanceIDHeader request header.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Time the request was received, in RFC 3339 format.
+	Time string `json:"time"`
+
+	// Duration in milliseconds from receiving the request to completing the
+	// response.
+	Duration float64 `json:"duration"`
+
+	Request  HTTPStreamRequest  `json:"request"`
+	Response HTTPStreamResponse `json:"response"`
+}
+
+// HTTPStreamRequest is the request of a HTTPStreamEvent.
+type HTTPStreamRequest struct {
+	Method        string      `json:"method"`
+	URL           string      `json:"url"`
+	Headers       http.Header `json:"headers"`
+	Body          string      `json:"body,omitempty"`
+	BodyTruncated bool        `json:"bodyTruncated,omitempty"`
+}
+
+// HTTPStreamResponse is the response of a HTTPStreamEvent.
+type HTTPStreamResponse struct {
+	Status        int         `json:"status"`
+	Headers       http.Header `json:"headers"`
+	Body          string      `json:"body,omitempty"`
+	BodyTruncated bool        `json:"bodyTruncated,omitempty"`
+}
+
+// httpStreamSubscriber receives the events matching its filter.
+type httpStreamSubscriber struct {
+	events chan HTTPStreamEvent
+	filter func(*HTTPStreamEvent) bool
+}
+
+// httpStreamCall contains the request details of an in-flight call, captured
+// before the request is handled.
+type httpStreamCall struct {
+	requestBody []byte
+	start       time.Time
+
+	// Operation details, set via setHTTPStreamOperation while handling.
+	operationId string
+	call        int64
+}
+
+// httpStreamCallKey is the context key of the in-flight httpStreamCall.
+type httpStreamCallKey struct{}
+
+// NewHTTPStream creates a HTTPStream which truncates bodies at maxBodySize
+// bytes. A maxBodySize of zero disables truncation.
+func NewHTTPStream(maxBodySize int) *HTTPStream {
+	return &HTTPStream{
+		maxBodySize: maxBodySize,
+		mutex:       new(sync.RWMutex),
+		subscribers: make(map[*httpStreamSubscriber]struct{}),
+	}
+}
+
+// Close ends all subscriptions by closing their event channels.
+func (s *HTTPStream) Close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for subscriber := range s.subscribers {
+		close(subscriber.events)
+		delete(s.subscribers, subscriber)
+	}
+}
+
+// HasSubscribers returns whether any subscription is active.
+func (s *HTTPStream) HasSubscribers() bool {
+	if s == nil {
+		return false
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.subscribers) > 0
+}
+
+// Subscribe returns a channel of all future events matching filter, which
+// may be nil to match all events, and a function to end the subscription.
+// Events are dropped for subscribers which fall too far behind.
+func (s *HTTPStream) Subscribe(filter func(*HTTPStreamEvent) bool) (<-chan HTTPStreamEvent, func()) {
+	subscriber := &httpStreamSubscriber{
+		events: make(chan HTTPStreamEvent, httpStreamSubscriberBuffer),
+		filter: filter,
+	}
+
+	s.mutex.Lock()
+	s.subscribers[subscriber] = struct{}{}
+	s.mutex.Unlock()
+
+	unsubscribe := func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if _, ok := s.subscribers[subscriber]; ok {
+			close(subscriber.events)
+			delete(s.subscribers, subscriber)
+		}
+	}
+
+	return subscriber.events, unsubscribe
+}
+
+// startCall captures the request body and start time of a call and returns a
+// request whose context allows operation details to be attached.
+func (s *HTTPStream) startCall(req *http.Request) (*httpStreamCall, *http.Request) {
+	result := &httpStreamCall{
+		start: time.Now(),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, _ := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		result.requestBody = body
+	}
+
+	return result, req.WithContext(context.WithValue(req.Context(), httpStreamCallKey{}, result))
+}
+
+// publish sends a completed call to all subscribers with a matching filter.
+func (s *HTTPStream) publish(call *httpStreamCall, req *http.Request, resp *http.Response, respBody []byte) {
+	event := HTTPStreamEvent{
+		OperationID: call.operationId,
+		Call:        call.call,
+		Namespace:   req.Header.Get(tracking.TestInstanceIDHeader),
+		Time:        call.start.UTC().Format(time.RFC3339Nano),
+		Duration:    float64(time.Since(call.start).Microseconds()) / 1000,
+		Request: HTTPStreamRequest{
+			Method:  req.Method,
+			URL:     req.URL.RequestURI(),
+			Headers: req.Header.Clone(),
+		},
+		Response: HTTPStreamResponse{
+			Status:  resp.StatusCode,
+			Headers: resp.Header.Clone(),
+		},
+	}
+
+	event.Request.Body, event.Request.BodyTruncated = s.truncate(call.requestBody)
+	event.Response.Body, event.Response.BodyTruncated = s.truncate(respBody)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextID++
+	event.ID = s.nextID
+
+	for subscriber := range s.subscribers {
+		if subscriber.filter != nil && !subscriber.filter(&event) {
+			continue
+		}
+
+		select {
+		case subscriber.events <- event:
+		default:
+			// Subscriber is too far behind, drop the event rather than
+			// blocking request handling.
+		}
+	}
+}
+
+// truncate returns the body as a string, truncated at the maximum body size.
+func (s *HTTPStream) truncate(body []byte) (string, bool) {
+	if s.maxBodySize > 0 && len(body) > s.maxBodySize {
+		return string(body[:s.maxBodySize]), true
+	}
+
+	return string(body), false
+}
+
+// setHTTPStreamOperation attaches operation details to the in-flight call of
+// the request context, if any.
+func setHTTPStreamOperation(ctx context.Context, operationId string, call int64) {
+	streamCall, ok := ctx.Value(httpStreamCallKey{}).(*httpStreamCall)
+
+	if !ok {
+		return
+	}
+
+	streamCall.operationId = operationId
+	streamCall.call = call
+}
@@ -0,0 +1,162 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serveStreamCall sends a request with body through a logger handler
+// publishing to the stream, which responds with respBody.
+func serveStreamCall(t *testing.T, stream *HTTPStream, body string, respBody string) {
+	t.Helper()
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	handler := HTTPLoggerHandler(logger, stream, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// The handler must still receive the complete request body.
+		if got, _ := io.ReadAll(req.Body); string(got) != body {
+			t.Errorf("expected handler request body %q, got: %q", body, got)
+		}
+
+		_, _ = w.Write([]byte(respBody))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/a?b=c", strings.NewReader(body)))
+}
+
+func TestHTTPStreamTruncate(t *testing.T) {
+	stream := NewHTTPStream(4)
+	events, unsubscribe := stream.Subscribe(nil)
+
+	defer unsubscribe()
+
+	for _, test := range []struct {
+		body                  string
+		expectedBody          string
+		expectedBodyTruncated bool
+	}{
+		{body: "", expectedBody: "", expectedBodyTruncated: false},
+		{body: "abcd", expectedBody: "abcd", expectedBodyTruncated: false},
+		{body: "abcde", expectedBody: "abcd", expectedBodyTruncated: true},
+	} {
+		serveStreamCall(t, stream, test.body, test.body)
+
+		event := <-events
+
+		if event.Request.Body != test.expectedBody || event.Request.BodyTruncated != test.expectedBodyTruncated {
+			t.Errorf("%q: expected request body %q truncated %t, got: %q %t", test.body, test.expectedBody, test.expectedBodyTruncated, event.Request.Body, event.Request.BodyTruncated)
+		}
+
+		if event.Response.Body != test.expectedBody || event.Response.BodyTruncated != test.expectedBodyTruncated {
+			t.Errorf("%q: expected response body %q truncated %t, got: %q %t", test.body, test.expectedBody, test.expectedBodyTruncated, event.Response.Body, event.Response.BodyTruncated)
+		}
+
+		if event.Request.URL != "/a?b=c" || event.Response.Status != http.StatusOK {
+			t.Errorf("%q: expected /a?b=c with status 200, got: %s %d", test.body, event.Request.URL, event.Response.Status)
+		}
+	}
+
+	// A maximum body size of zero disables truncation.
+	unlimited := NewHTTPStream(0)
+	unlimitedEvents, unlimitedUnsubscribe := unlimited.Subscribe(nil)
+
+	defer unlimitedUnsubscribe()
+
+	body := strings.Repeat("a", DefaultHTTPStreamMaxBodySize+1)
+
+	serveStreamCall(t, unlimited, body, "")
+
+	if event := <-unlimitedEvents; event.Request.Body != body || event.Request.BodyTruncated {
+		t.Errorf("expected untruncated body of %d bytes, got: %d bytes truncated %t", len(body), len(event.Request.Body), event.Request.BodyTruncated)
+	}
+}
+
+func TestHTTPStreamDropSlowSubscriber(t *testing.T) {
+	stream := NewHTTPStream(DefaultHTTPStreamMaxBodySize)
+	slow, unsubscribeSlow := stream.Subscribe(nil)
+
+	defer unsubscribeSlow()
+
+	fast, unsubscribeFast := stream.Subscribe(nil)
+
+	defer unsubscribeFast()
+
+	// Publishing must not block on the slow subscriber, which never reads.
+	var fastIDs []int64
+
+	for range httpStreamSubscriberBuffer + 2 {
+		serveStreamCall(t, stream, "", "")
+
+		fastIDs = append(fastIDs, (<-fast).ID)
+	}
+
+	if got := len(slow); got != httpStreamSubscriberBuffer {
+		t.Fatalf("expected %d buffered events, got: %d", httpStreamSubscriberBuffer, got)
+	}
+
+	// The slow subscriber keeps the oldest events, while the others are
+	// dropped.
+	for i := range httpStreamSubscriberBuffer {
+		if event := <-slow; event.ID != int64(i+1) {
+			t.Fatalf("expected event %d, got: %d", i+1, event.ID)
+		}
+	}
+
+	if got := fastIDs[len(fastIDs)-1]; got != httpStreamSubscriberBuffer+2 {
+		t.Errorf("expected fast subscriber to receive every event, got last: %d", got)
+	}
+
+	serveStreamCall(t, stream, "", "")
+
+	if event := <-slow; event.ID != httpStreamSubscriberBuffer+3 {
+		t.Errorf("expected event %d once the subscriber caught up, got: %d", httpStreamSubscriberBuffer+3, event.ID)
+	}
+}
+
+func TestHTTPStreamFilter(t *testing.T) {
+	stream := NewHTTPStream(DefaultHTTPStreamMaxBodySize)
+	events, unsubscribe := stream.Subscribe(func(event *HTTPStreamEvent) bool {
+		return event.Request.Body == "match"
+	})
+
+	defer unsubscribe()
+
+	serveStreamCall(t, stream, "other", "")
+	serveStreamCall(t, stream, "match", "")
+
+	if event := <-events; event.Request.Body != "match" || event.ID != 2 {
+		t.Errorf("expected only the matching event 2, got: %d %q", event.ID, event.Request.Body)
+	}
+}
+
+func TestHTTPStreamClose(t *testing.T) {
+	stream := NewHTTPStream(DefaultHTTPStreamMaxBodySize)
+	events, unsubscribe := stream.Subscribe(nil)
+
+	serveStreamCall(t, stream, "", "")
+
+	stream.Close()
+
+	// Buffered events are still received before the channel is closed.
+	if _, ok := <-events; !ok {
+		t.Fatal("expected buffered event before close")
+	}
+
+	if _, ok := <-events; ok {
+		t.Fatal("expected closed channel")
+	}
+
+	if stream.HasSubscribers() {
+		t.Error("expected no subscribers after close")
+	}
+
+	// Unsubscribing after Close must not close the channel again.
+	unsubscribe()
+
+	// Calls after Close are not captured for streaming.
+	serveStreamCall(t, stream, "body", "")
+}
@@ -0,0 +1,138 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"mockserver/internal/tracking"
+)
+
+// JournalEntry is a structured view of a single logged operation call.
+type JournalEntry struct {
+	// OAS operation identifier.
+	OperationID string `json:"operationId"`
+
+	// OAS operation call number.
+	Call int64 `json:"call"`
+
+	// Test namespace, from the tracking.TestInstanceIDHeader request header.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Order in which the request was received, across all operations.
+	Sequence int64 `json:"sequence"`
+
+	// Time the request was received.
+	Time time.Time `json:"time"`
+
+	Request  JournalRequest  `json:"request"`
+	Response JournalResponse `json:"response"`
+}
+
+// JournalRequest is the request of a JournalEntry.
+type JournalRequest struct {
+	Method  string      `json:"method"`
+	Path    string      `json:"path"`
+	Query   url.Values  `json:"query"`
+	Headers http.Header `json:"headers"`
+
+	// Decoded body, if the body is JSON.
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// Raw body, if the body is not JSON.
+	BodyText string `json:"bodyText,omitempty"`
+}
+
+// JournalResponse is the response of a JournalEntry.
+type JournalResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers"`
+
+	// Decoded body, if the body is JSON.
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// Raw body, if the body is not JSON.
+	BodyText string `json:"bodyText,omitempty"`
+}
+
+// NewJournalEntry converts a logged operation call into a JournalEntry.
+func NewJournalEntry(call *OASOperationCall) (*JournalEntry, error) {
+	req, err := call.Request()
+	if err != nil {
+		return nil, fmt.Errorf("error reading operation %s call %d request: %w", call.Operation().Id(), call.Call(), err)
+	}
+
+	reqBody, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading operation %s call %d request body: %w", call.Operation().Id(), call.Call(), err)
+	}
+
+	resp, err := call.Response()
+	if err != nil {
+		return nil, fmt.Errorf("error reading operation %s call %d response: %w", call.Operation().Id(), call.Call(), err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading operation %s call %d response body: %w", call.Operation().Id(), call.Call(), err)
+	}
+
+	sequence, err := call.Sequence()
+	if err != nil {
+		return nil, err
+	}
+
+	requestTime, err := call.RequestTime()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &JournalEntry{
+		OperationID: call.Operation().Id(),
+		Call:        call.Call(),
+		Sequence:    sequence,
+		Namespace:   req.Header.Get(tracking.TestInstanceIDHeader),
+		Time:        requestTime.UTC(),
+		Request: JournalRequest{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   req.URL.Query(),
+			Headers: req.Header,
+		},
+		Response: JournalResponse{
+			Status:  resp.StatusCode,
+			Headers: resp.Header,
+		},
+	}
+
+	result.Request.Body, result.Request.BodyText = journalBody(req.Header.Get("Content-Type"), reqBody)
+	result.Response.Body, result.Response.BodyText = journalBody(resp.Header.Get("Content-Type"), respBody)
+
+	return result, nil
+}
+
+// journalBody returns the body as JSON if it is valid JSON with a JSON content
+// type, otherwise as text.
+func journalBody(contentType string, body []byte) (json.RawMessage, string) {
+	if len(body) == 0 {
+		return nil, ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	if (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")) && json.Valid(body) {
+		var compacted bytes.Buffer
+
+		if err := json.Compact(&compacted, body); err == nil {
+			return compacted.Bytes(), ""
+		}
+	}
+
+	return nil, string(body)
+}
@@ -0,0 +1,28 @@
+package logging
+
+import "testing"
+
+func TestJournalBody(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		contentType  string
+		body         string
+		expectedJSON string
+		expectedText string
+	}{
+		{name: "empty", contentType: "application/json", body: "", expectedJSON: "", expectedText: ""},
+		{name: "json", contentType: "application/json", body: "{\n  \"a\": [1, 2]\n}", expectedJSON: `{"a":[1,2]}`, expectedText: ""},
+		{name: "json with parameters", contentType: "application/json; charset=utf-8", body: `"text"`, expectedJSON: `"text"`, expectedText: ""},
+		{name: "json suffix", contentType: "application/problem+json", body: `{"title":"x"}`, expectedJSON: `{"title":"x"}`, expectedText: ""},
+		{name: "invalid json", contentType: "application/json", body: `{"a":`, expectedJSON: "", expectedText: `{"a":`},
+		{name: "json without content type", contentType: "", body: `{"a":1}`, expectedJSON: "", expectedText: `{"a":1}`},
+		{name: "text", contentType: "text/plain", body: "hello", expectedJSON: "", expectedText: "hello"},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: "a=1&b=2", expectedJSON: "", expectedText: "a=1&b=2"},
+	} {
+		gotJSON, gotText := journalBody(test.contentType, []byte(test.body))
+
+		if string(gotJSON) != test.expectedJSON || gotText != test.expectedText {
+			t.Errorf("%s: expected JSON %q and text %q, got: %q %q", test.name, test.expectedJSON, test.expectedText, gotJSON, gotText)
+		}
+	}
+}
@@ -1,8 +1,9 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package logging
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
 
 // OASOperation contains a singular OAS operation. An operation can have one or
 // more calls.
@@ -27,28 +28,33 @@ type OASOperation struct {
 
 // NewOASOperation creates a new OASOperation.
 func NewOASOperation(dir *HTTPFileDirectory, operationId string, method string, path string) *OASOperation {
-	operationCalls := dir.OperationCallCount(operationId)
+	operationCalls := dir.OperationStoredCalls(operationId)
 	result := &OASOperation{
-		calls:  make([]*OASOperationCall, 0, operationCalls),
+		calls:  make([]*OASOperationCall, 0, len(operationCalls)),
 		dir:    dir,
 		id:     operationId,
 		method: method,
 		path:   path,
 	}
 
-	for i := range operationCalls {
-		// Start at 1 for human consumption.
-		result.calls = append(result.calls, NewOASOperationCall(result, i+1))
+	for _, call := range operationCalls {
+		result.calls = append(result.calls, NewOASOperationCall(result, call))
 	}
 
 	return result
 }
 
-// CallCount returns the number of calls to an operation.
+// CallCount returns the number of stored calls to an operation.
 func (o *OASOperation) CallCount() int64 {
 	return int64(len(o.calls))
 }
 
+// Calls returns the stored calls to an operation, oldest first. Their files
+// may be evicted afterwards, so errors reading them can match [IsEvicted].
+func (o *OASOperation) Calls() []*OASOperationCall {
+	return o.calls
+}
+
 // Id returns the operation identifier as defined in OAS.
 func (o *OASOperation) Id() string {
 	return o.id
@@ -81,6 +87,22 @@ func (o *OASOperation) Request(call int64) (*http.Request, error) {
 	return o.dir.Request(o.id, call)
 }
 
+// RequestTime returns when the server received the HTTP request.
+func (o *OASOperation) RequestTime(call int64) (time.Time, error) {
+	return o.dir.RequestTime(o.id, call)
+}
+
+// ResponseTime returns when the server completed the HTTP response.
+func (o *OASOperation) ResponseTime(call int64) (time.Time, error) {
+	return o.dir.ResponseTime(o.id, call)
+}
+
+// Sequence returns the order in which the server received the call, across
+// all operations.
+func (o *OASOperation) Sequence(call int64) (int64, error) {
+	return o.dir.Sequence(o.id, call)
+}
+
 // Response returns the parsed HTTP response contents.
 func (o *OASOperation) Response(call int64) (*http.Response, error) {
 	return o.dir.Response(o.id, call)
@@ -1,11 +1,10 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package logging
 
 import (
 	"bufio"
 	"bytes"
 	"net/http"
+	"time"
 )
 
 // OASOperationCall contains a singular OAS operation HTTP call.
@@ -40,6 +39,11 @@ func NewOASOperationCall(operation *OASOperation, call int64) *OASOperationCall
 	}
 }
 
+// Call returns the HTTP call number, as determined by the HTTP server.
+func (c *OASOperationCall) Call() int64 {
+	return c.call
+}
+
 // RawRequest returns the raw HTTP request contents as dumped by
 // [httputil.DumpRequest].
 func (c *OASOperationCall) RawRequest() ([]byte, error) {
@@ -99,6 +103,27 @@ func (c *OASOperationCall) Request() (*http.Request, error) {
 	return c.request, nil
 }
 
+// Operation returns the associated OAS operation to this call.
+func (c *OASOperationCall) Operation() *OASOperation {
+	return c.operation
+}
+
+// RequestTime returns when the server received the HTTP request.
+func (c *OASOperationCall) RequestTime() (time.Time, error) {
+	return c.operation.RequestTime(c.call)
+}
+
+// ResponseTime returns when the server completed the HTTP response.
+func (c *OASOperationCall) ResponseTime() (time.Time, error) {
+	return c.operation.ResponseTime(c.call)
+}
+
+// Sequence returns the order in which the server received the call, across
+// all operations.
+func (c *OASOperationCall) Sequence() (int64, error) {
+	return c.operation.Sequence(c.call)
+}
+
 // Response returns the parsed HTTP response contents.
 func (c *OASOperationCall) Response() (*http.Response, error) {
 	if c.response != nil {
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"mockserver/internal/catalog"
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/sdk/models/sdkerrors"
+	"mockserver/internal/sdk/utils"
+	"mockserver/internal/state"
+)
+
+// apiError is an error response of an emulated API operation.
+type apiError struct {
+	// HTTP status code of the response.
+	statusCode int
+
+	// Error message.
+	message string
+
+	// Validation errors keyed by field name. When set, the response is a
+	// ValidationErrorDto instead of an ErrorDto.
+	validation map[string]components.ConstraintValidation
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// newAPIError returns an error response with the formatted message.
+func newAPIError(statusCode int, format string, args ...any) *apiError {
+	return &apiError{
+		statusCode: statusCode,
+		message:    fmt.Sprintf(format, args...),
+	}
+}
+
+// newValidationError returns a 422 Unprocessable Entity validation error
+// response for a single field.
+func newValidationError(field string, message string) *apiError {
+	return &apiError{
+		statusCode: http.StatusUnprocessableEntity,
+		message:    "Validation Error",
+		validation: map[string]components.ConstraintValidation{
+			field: {Messages: []string{message}},
+		},
+	}
+}
+
+// registerAPIHandlers adds the handlers emulating API operations over the
+// in-memory state. Calls are logged like those of generated handlers.
+func (s *Server) registerAPIHandlers(ctx context.Context) {
+	s.logger.Debug("registering API handlers")
+
+	handlers := map[string]http.HandlerFunc{
+		"EnvironmentsControllerV1_updateMyEnvironment":        s.environmentUpdateHandler,
+		"EventsController_broadcastEventToAll":                s.eventBroadcastHandler,
+		"EventsController_trigger":                            s.eventTriggerHandler,
+		"EventsController_triggerBulk":                        s.eventTriggerBulkHandler,
+		"LayoutsController_create":                            s.layoutCreateHandler,
+		"LayoutsController_delete":                            s.layoutDeleteHandler,
+		"LayoutsController_duplicate":                         s.layoutDuplicateHandler,
+		"LayoutsController_get":                               s.layoutHandler,
+		"LayoutsController_list":                              s.layoutsHandler,
+		"LayoutsController_update":                            s.layoutUpdateHandler,
+		"SubscribersController_getSubscriber":                 s.subscriberHandler,
+		"SubscribersController_searchSubscribers":             s.subscribersHandler,
+		"SubscribersV1Controller_bulkCreateSubscribers":       s.subscribersBulkCreateHandler,
+		"SubscribersV1Controller_deleteSubscriberCredentials": s.subscriberCredentialsDeleteHandler,
+		"SubscribersV1Controller_modifySubscriberChannel":     s.subscriberChannelModifyHandler,
+		"SubscribersV1Controller_updateSubscriberChannel":     s.subscriberChannelHandler,
+		"SubscribersV1Controller_updateSubscriberOnlineFlag":  s.subscriberOnlineFlagHandler,
+		"WorkflowController_duplicateWorkflow":                s.workflowDuplicateHandler,
+		"WorkflowController_getWorkflowStepData":              s.workflowStepDataHandler,
+		"WorkflowController_sync":                             s.workflowSyncHandler,
+	}
+
+	for _, op := range catalog.RoutingOrder() {
+		handler, ok := handlers[op.ID]
+
+		if !ok {
+			continue
+		}
+
+		s.RegisterHandlerFunc(ctx, []string{op.Method}, op.Path, s.httpFileDir.HandlerFunc(op.ID, handler))
+	}
+}
+
+// now returns the current time of emulated API operations.
+func (s *Server) now() time.Time {
+	return s.clock()
+}
+
+// apiKey returns the API key of the Authorization request header, which may
+// use the ApiKey or Bearer scheme.
+func apiKey(req *http.Request) string {
+	value := req.Header.Get("Authorization")
+
+	for _, scheme := range []string{"ApiKey ", "Bearer "} {
+		if len(value) > len(scheme) && strings.EqualFold(value[:len(scheme)], scheme) {
+			return strings.TrimSpace(value[len(scheme):])
+		}
+	}
+
+	return strings.TrimSpace(value)
+}
+
+// apiEnvironmentIndex returns the index of the environment authenticated by
+// the API key of the request.
+func apiEnvironmentIndex(req *http.Request, environments []state.EnvironmentState) (int, error) {
+	index := state.EnvironmentIndexByAPIKey(environments, apiKey(req))
+
+	if index < 0 {
+		return -1, newAPIError(http.StatusUnauthorized, "API Key not found")
+	}
+
+	return index, nil
+}
+
+// apiEnvironment returns the environment authenticated by the API key of the
+// request.
+func (s *Server) apiEnvironment(req *http.Request) (*state.EnvironmentState, error) {
+	environments := s.state.Environments()
+
+	index, err := apiEnvironmentIndex(req, environments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &environments[index], nil
+}
+
+// updateAPIEnvironment calls fn with the environment authenticated by the API
+// key of the request via [state.Store.Update].
+func (s *Server) updateAPIEnvironment(req *http.Request, fn func(environment *state.EnvironmentState) error) error {
+	return s.state.Update(func(environments []state.EnvironmentState) error {
+		index, err := apiEnvironmentIndex(req, environments)
+
+		if err != nil {
+			return err
+		}
+
+		return fn(&environments[index])
+	})
+}
+
+// queryInt returns the non-negative integer query parameter, or defaultValue
+// if it is not set.
+func queryInt(req *http.Request, name string, defaultValue int) (int, error) {
+	value := req.URL.Query().Get(name)
+
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	result, err := strconv.Atoi(value)
+
+	if err != nil || result < 0 {
+		return 0, newValidationError(name, name+" must be a non-negative integer")
+	}
+
+	return result, nil
+}
+
+// decodeAPIRequest decodes the JSON request body into v via
+// [utils.UnmarshalJSON]. An empty body is decoded as an empty object.
+func decodeAPIRequest(req *http.Request, v any) error {
+	body, err := io.ReadAll(req.Body)
+
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, "request body error: %s", err)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		body = []byte("{}")
+	}
+
+	err = utils.UnmarshalJSON(body, v, "", true, false)
+
+	if err != nil {
+		return newAPIError(http.StatusBadRequest, "request body error: %s", err)
+	}
+
+	return nil
+}
+
+// writeAPIResponse writes v as the JSON response body via [utils.MarshalJSON].
+func writeAPIResponse(w http.ResponseWriter, statusCode int, v any) {
+	body, err := utils.MarshalJSON(v, "", true)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("response encoding error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// writeAPIError writes err as an ErrorDto or ValidationErrorDto response. State
+// errors are mapped to the status code of their kind, such as 404 Not Found
+// for [state.ErrNotFound].
+func (s *Server) writeAPIError(w http.ResponseWriter, req *http.Request, err error) {
+	var target *apiError
+
+	if !errors.As(err, &target) {
+		target = newAPIError(http.StatusInternalServerError, "%s", err)
+
+		switch {
+		case errors.Is(err, state.ErrConflict):
+			target.statusCode = http.StatusConflict
+		case errors.Is(err, state.ErrInvalid):
+			target.statusCode = http.StatusUnprocessableEntity
+		case errors.Is(err, state.ErrNotFound):
+			target.statusCode = http.StatusNotFound
+		}
+	}
+
+	timestamp := state.FormatTime(s.now())
+
+	if target.validation != nil {
+		message := components.CreateValidationErrorDtoMessageUnion2Str(target.message)
+
+		writeAPIResponse(w, target.statusCode, sdkerrors.ValidationErrorDto{
+			StatusCode: float64(target.statusCode),
+			Timestamp:  timestamp,
+			Path:       req.URL.Path,
+			Message:    &message,
+			Errors:     target.validation,
+		})
+
+		return
+	}
+
+	message := components.CreateErrorDtoMessageUnion2Str(target.message)
+
+	writeAPIResponse(w, target.statusCode, sdkerrors.ErrorDto{
+		StatusCode: float64(target.statusCode),
+		Timestamp:  timestamp,
+		Path:       req.URL.Path,
+		Message:    &message,
+	})
+}
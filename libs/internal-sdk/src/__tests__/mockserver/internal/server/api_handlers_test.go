@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mockserver/internal/sdk/utils"
+)
+
+// newTestServer returns a test server seeded with testdata/seed.yaml, logging
+// to a temporary directory.
+func newTestServer(t *testing.T, opts ...ServerOption) (*Server, *httptest.Server) {
+	t.Helper()
+
+	opts = append([]ServerOption{WithHTTPFileDirectory(t.TempDir()), WithSeed("testdata/seed.yaml")}, opts...)
+
+	s, err := NewServer(context.Background(), opts...)
+
+	if err != nil {
+		t.Fatalf("unexpected error creating server: %s", err)
+	}
+
+	ts := httptest.NewServer(s.Handler())
+
+	t.Cleanup(ts.Close)
+
+	return s, ts
+}
+
+// apiCall sends a request with the API key and JSON body, returning the
+// response status code and body.
+func apiCall(t *testing.T, ts *httptest.Server, method string, path string, key string, body string) (int, []byte) {
+	t.Helper()
+
+	var reader io.Reader
+
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, ts.URL+path, reader)
+
+	if err != nil {
+		t.Fatalf("unexpected error creating request: %s", err)
+	}
+
+	if key != "" {
+		req.Header.Set("Authorization", "ApiKey "+key)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := ts.Client().Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error sending request: %s", err)
+	}
+
+	defer res.Body.Close()
+
+	result, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		t.Fatalf("unexpected error reading response: %s", err)
+	}
+
+	return res.StatusCode, result
+}
+
+// decodeAPIResponse decodes a JSON response body into v via
+// [utils.UnmarshalJSON].
+func decodeAPIResponse(t *testing.T, body []byte, v any) {
+	t.Helper()
+
+	err := utils.UnmarshalJSON(body, v, "", true, false)
+
+	if err != nil {
+		t.Fatalf("unexpected error decoding response %s: %s", body, err)
+	}
+}
+
+func TestAPIUnauthorized(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	for _, key := range []string{"", "unknown-key"} {
+		status, body := apiCall(t, ts, http.MethodPut, "/v2/workflows/welcome/sync", key, `{"targetEnvironmentId":"env-prod"}`)
+
+		if status != http.StatusUnauthorized {
+			t.Errorf("key %q: expected status 401, got: %d %s", key, status, body)
+		}
+
+		if !strings.Contains(string(body), `"statusCode":401`) {
+			t.Errorf("key %q: expected ErrorDto body, got: %s", key, body)
+		}
+	}
+}
+
+func TestAPIHandlersLogged(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	apiCall(t, ts, http.MethodPut, "/v2/workflows/welcome/sync", "dev-key", `{"targetEnvironmentId":"env-prod"}`)
+
+	if got := len(s.httpFileDir.OperationStoredCalls("WorkflowController_sync")); got != 1 {
+		t.Errorf("expected 1 logged call, got: %d", got)
+	}
+}
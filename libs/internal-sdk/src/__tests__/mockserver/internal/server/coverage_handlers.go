@@ -0,0 +1,83 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"mockserver/internal/coverage"
+)
+
+// coverageHandler returns the operation coverage report as JSON, or as a HTML
+// page for the format=html query parameter or requests accepting HTML.
+func (s *Server) coverageHandler(w http.ResponseWriter, req *http.Request) {
+	report, err := coverage.NewReport(s.httpFileDir)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("coverage report error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	format := req.URL.Query().Get("format")
+
+	if format == "" && strings.Contains(req.Header.Get("Accept"), "text/html") {
+		format = "html"
+	}
+
+	switch format {
+	case "", "json":
+		writeJSON(w, http.StatusOK, report)
+	case "html":
+		s.coverageHTMLHandler(w, report)
+	default:
+		http.Error(w, fmt.Sprintf("unsupported coverage format: %s", format), http.StatusBadRequest)
+	}
+}
+
+// coverageHTMLHandler writes the operation coverage report as a HTML page.
+func (s *Server) coverageHTMLHandler(w http.ResponseWriter, report *coverage.Report) {
+	tmpl := template.New("coverage.html.tmpl")
+	tmpl.Funcs(template.FuncMap{
+		"mod": func(i, j int) bool { return i%j == 0 },
+	})
+	_, err := tmpl.ParseFS(
+		logTemplates,
+		"templates/log/style.css.tmpl",
+		"templates/log/coverage.html.tmpl",
+	)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("coverage template error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	var wBuf bytes.Buffer
+
+	err = tmpl.Execute(&wBuf, report)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("coverage template execution error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = wBuf.WriteTo(w)
+}
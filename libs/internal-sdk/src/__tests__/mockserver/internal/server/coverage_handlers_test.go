@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"mockserver/internal/coverage"
+)
+
+func TestShutdownWritesCoverageFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.json")
+	s, ts := newTestServer(t, WithCoverageFile(path))
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"alice"}`)
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no coverage file before shutdown, got: %v", err)
+	}
+
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("expected coverage file after shutdown, got: %s", err)
+	}
+
+	var report coverage.Report
+
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var triggerCalls, createdCalls int64
+
+	for _, operation := range report.Operations {
+		if operation.ID != "EventsController_trigger" {
+			continue
+		}
+
+		triggerCalls = operation.Calls
+
+		for _, statusCode := range operation.StatusCodes {
+			if statusCode.Code == http.StatusCreated {
+				createdCalls = int64(statusCode.Calls)
+			}
+		}
+	}
+
+	if triggerCalls != 1 || createdCalls != 1 {
+		t.Errorf("expected 1 trigger call with status 201, got: %d calls, %d with status 201", triggerCalls, createdCalls)
+	}
+
+	var strCalls int
+
+	for _, union := range report.Unions {
+		if union.Name != "components.ToUnion2" {
+			continue
+		}
+
+		for _, variant := range union.Variants {
+			if variant.Name == "Str" {
+				strCalls = variant.Calls
+			}
+		}
+	}
+
+	if strCalls != 1 {
+		t.Errorf("expected 1 call with the Str variant of components.ToUnion2, got: %d", strCalls)
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/state"
+
+	"github.com/gorilla/mux"
+)
+
+// environmentUpdateHandler updates an environment of the organization of the
+// authenticated environment, such as its bridge application URL.
+func (s *Server) environmentUpdateHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.UpdateEnvironmentRequestDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result components.EnvironmentResponseDto
+
+	err = s.state.Update(func(environments []state.EnvironmentState) error {
+		index, err := apiEnvironmentIndex(req, environments)
+
+		if err != nil {
+			return err
+		}
+
+		id := mux.Vars(req)["environmentId"]
+		target := state.EnvironmentIndex(environments, id)
+
+		if target < 0 || environments[target].Environment.OrganizationID != environments[index].Environment.OrganizationID {
+			return newAPIError(http.StatusNotFound, "Environment %s not found", id)
+		}
+
+		environment, err := state.UpdateEnvironment(environments, id, body)
+
+		if err != nil {
+			return err
+		}
+
+		result = environment.Environment
+
+		return nil
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
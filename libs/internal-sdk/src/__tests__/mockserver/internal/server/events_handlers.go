@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/state"
+)
+
+const (
+	// Maximum number of events of a bulk trigger.
+	maxBulkTriggerEvents = 100
+)
+
+// eventTriggerHandler triggers a workflow of the authenticated environment.
+func (s *Server) eventTriggerHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.TriggerEventRequestDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var notifications []components.ActivityNotificationResponseDto
+	var result *components.TriggerEventResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		created := len(environment.Notifications)
+		result, err = state.TriggerEvent(environment, body, s.now())
+		notifications = slices.Clone(environment.Notifications[created:])
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	s.dispatchNotificationEvents(notifications)
+	writeAPIResponse(w, http.StatusCreated, result)
+}
+
+// eventBroadcastHandler triggers a workflow of the authenticated environment
+// for all of its subscribers.
+func (s *Server) eventBroadcastHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.TriggerEventToAllRequestDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var notifications []components.ActivityNotificationResponseDto
+	var result *components.TriggerEventResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		created := len(environment.Notifications)
+		result, err = state.BroadcastEvent(environment, body, s.now())
+		notifications = slices.Clone(environment.Notifications[created:])
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	s.dispatchNotificationEvents(notifications)
+	writeAPIResponse(w, http.StatusCreated, result)
+}
+
+// eventTriggerBulkHandler triggers each event of the request body
+// independently, returning a result per event.
+func (s *Server) eventTriggerBulkHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.BulkTriggerEventDto
+
+	err := decodeAPIRequest(req, &body)
+
+	switch {
+	case err != nil:
+	case len(body.Events) == 0:
+		err = newValidationError("events", "events should not be empty")
+	case len(body.Events) > maxBulkTriggerEvents:
+		err = newValidationError("events", fmt.Sprintf("events must contain no more than %d elements", maxBulkTriggerEvents))
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var notifications []components.ActivityNotificationResponseDto
+	var result []components.TriggerEventResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		created := len(environment.Notifications)
+		result = state.TriggerBulk(environment, body.Events, s.now())
+		notifications = slices.Clone(environment.Notifications[created:])
+
+		return nil
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	s.dispatchNotificationEvents(notifications)
+	writeAPIResponse(w, http.StatusCreated, result)
+}
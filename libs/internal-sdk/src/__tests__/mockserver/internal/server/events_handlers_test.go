@@ -0,0 +1,101 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/sdk/utils"
+)
+
+func TestEventTriggerHandler(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"alice","payload":{"name":"Alice"}}`)
+
+	var result components.TriggerEventResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if status != http.StatusCreated || result.Status != components.TriggerEventResponseDtoStatusProcessed {
+		t.Errorf("expected processed trigger, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"unknown","to":"alice"}`)
+
+	if status != http.StatusUnprocessableEntity || !strings.Contains(string(body), "workflow_not_found") {
+		t.Errorf("expected 422 workflow_not_found, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"alice","tenant":"unknown"}`)
+
+	if status != http.StatusUnprocessableEntity || !strings.Contains(string(body), "no_tenant_found") {
+		t.Errorf("expected 422 no_tenant_found, got: %d %s", status, body)
+	}
+}
+
+func TestEventTriggerBulkHandler(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v1/events/trigger/bulk", "dev-key", `{"events":[{"name":"welcome","to":"alice"},{"name":"unknown","to":"alice"}]}`)
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	var results []components.TriggerEventResponseDto
+
+	decodeAPIResponse(t, body, &results)
+
+	if len(results) != 2 || results[0].Status != components.TriggerEventResponseDtoStatusProcessed || results[1].Status != components.TriggerEventResponseDtoStatusError {
+		t.Errorf("expected processed and error results, got: %s", body)
+	}
+
+	events := make([]components.TriggerEventRequestDto, maxBulkTriggerEvents+1)
+
+	for i := range events {
+		events[i] = components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str(fmt.Sprintf("subscriber-%d", i))}
+	}
+
+	request, err := utils.MarshalJSON(components.BulkTriggerEventDto{Events: events}, "", true)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v1/events/trigger/bulk", "dev-key", string(request))
+
+	if status != http.StatusUnprocessableEntity || !strings.Contains(string(body), `"events":{"messages":["events must contain no more than 100 elements"]`) {
+		t.Errorf("expected 422 validation error, got: %d %s", status, body)
+	}
+}
+
+func TestEventBroadcastHandler(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"bob"}`)
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v1/events/trigger/broadcast", "dev-key", `{"name":"welcome","payload":{}}`)
+
+	var result components.TriggerEventResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if status != http.StatusCreated || result.TransactionID == nil {
+		t.Fatalf("expected processed broadcast, got: %d %s", status, body)
+	}
+
+	var recipients []string
+
+	for _, notification := range s.state.Environments()[0].Notifications {
+		if notification.TransactionID == *result.TransactionID {
+			recipients = append(recipients, notification.Subscriber.SubscriberID)
+		}
+	}
+
+	if strings.Join(recipients, ",") != "alice,bob" {
+		t.Errorf("expected notifications for alice and bob, got: %v", recipients)
+	}
+}
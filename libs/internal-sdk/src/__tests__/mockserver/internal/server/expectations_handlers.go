@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"mockserver/internal/expectation"
+)
+
+// expectationHandler records every non-internal request against the
+// registered call expectations before passing it to next.
+func (s *Server) expectationHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, internalPathPrefix+"/") || s.expectations.Empty() {
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		body, err := io.ReadAll(req.Body)
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("request body error: %s", err), http.StatusBadRequest)
+
+			return
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		s.expectations.Record(req, body)
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// expectationsHandler returns the registered call expectations of the
+// namespace query parameter, or all of them, with their current counts.
+func (s *Server) expectationsHandler(w http.ResponseWriter, req *http.Request) {
+	type expectationsModel struct {
+		Expectations []*expectation.Expectation `json:"expectations"`
+	}
+
+	writeJSON(w, http.StatusOK, expectationsModel{
+		Expectations: s.expectations.List(req.URL.Query().Get("namespace")),
+	})
+}
+
+// expectationCreateHandler registers the call expectation in the request body.
+func (s *Server) expectationCreateHandler(w http.ResponseWriter, req *http.Request) {
+	var e expectation.Expectation
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&e)
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("expectation request body error: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	result, err := s.expectations.Add(e)
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid expectation: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// expectationsClearHandler removes the call expectations and recorded calls of
+// the namespace query parameter, or all of them.
+func (s *Server) expectationsClearHandler(w http.ResponseWriter, req *http.Request) {
+	s.expectations.Clear(req.URL.Query().Get("namespace"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// expectationsVerifyHandler reports unmet call expectations and unexpected
+// calls of the namespace query parameter, or all of them.
+func (s *Server) expectationsVerifyHandler(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, s.expectations.Verify(req.URL.Query().Get("namespace")))
+}
+
+// writeJSON writes v as an indented JSON response body with the status code.
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	body, err := json.MarshalIndent(v, "", "  ")
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("response encoding error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
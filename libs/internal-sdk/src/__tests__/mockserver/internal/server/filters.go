@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// newStatusFilter returns a function which matches response status codes
+// against any of the given values, which are either status classes, such as
+// 2xx, or exact status codes, such as 404. No values match all codes.
+func newStatusFilter(values []string) (func(int) bool, error) {
+	var classes []int
+	var codes []int
+
+	for _, value := range values {
+		lower := strings.ToLower(value)
+
+		if class, ok := strings.CutSuffix(lower, "xx"); ok {
+			if len(class) != 1 || class[0] < '1' || class[0] > '5' {
+				return nil, fmt.Errorf("invalid status class %q, expected one of 1xx, 2xx, 3xx, 4xx, 5xx", value)
+			}
+
+			classes = append(classes, int(class[0]-'0'))
+
+			continue
+		}
+
+		code, err := strconv.Atoi(lower)
+
+		if err != nil || code < 100 || code > 599 {
+			return nil, fmt.Errorf("invalid status %q, expected a status class, such as 2xx, or code, such as 404", value)
+		}
+
+		codes = append(codes, code)
+	}
+
+	result := func(status int) bool {
+		if len(classes) == 0 && len(codes) == 0 {
+			return true
+		}
+
+		return slices.Contains(classes, status/100) || slices.Contains(codes, status)
+	}
+
+	return result, nil
+}
+
+// splitQueryValues returns all non-empty comma-separated values of a query
+// parameter.
+func splitQueryValues(query url.Values, key string) []string {
+	var result []string
+
+	for _, value := range query[key] {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+
+			if item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result
+}
+
+// upperValues returns the values converted to upper case, such as for HTTP
+// methods.
+func upperValues(values []string) []string {
+	result := make([]string, len(values))
+
+	for i, value := range values {
+		result[i] = strings.ToUpper(value)
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"mockserver/internal/logging"
+
+	"github.com/gorilla/mux"
+)
+
+// httpFileHARHandler returns a HAR document of all logged HTTP operations.
+func (s *Server) httpFileHARHandler(w http.ResponseWriter, _ *http.Request) {
+	operations, err := s.httpFileDir.Operations()
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("operation log error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	writeHAR(w, "mockserver.har", operations)
+}
+
+// httpOperationHARHandler returns a HAR document of a single logged HTTP
+// operation.
+func (s *Server) httpOperationHARHandler(w http.ResponseWriter, req *http.Request) {
+	operationId := mux.Vars(req)["operationId"]
+
+	if operationId == "" || len(s.httpFileDir.OperationStoredCalls(operationId)) == 0 {
+		http.Error(w, "operation logs not found", http.StatusNotFound)
+
+		return
+	}
+
+	operation, err := s.httpFileDir.Operation(operationId)
+
+	if logging.IsEvicted(err) {
+		http.Error(w, "operation logs not found", http.StatusNotFound)
+
+		return
+	}
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("operation %s log error: %s", operationId, err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	writeHAR(w, operationId+".har", []*logging.OASOperation{operation})
+}
+
+// writeHAR converts the operations into a HAR document and writes it as a
+// downloadable file.
+func writeHAR(w http.ResponseWriter, filename string, operations []*logging.OASOperation) {
+	har, err := logging.NewHAR(operations)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("operation log HAR error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	body, err := json.MarshalIndent(har, "", "  ")
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("operation log HAR encoding error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
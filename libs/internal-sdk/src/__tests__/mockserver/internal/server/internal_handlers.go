@@ -1,16 +1,18 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package server
 
 import (
 	"bytes"
 	"context"
+	"embed"
 	"fmt"
 	"html/template"
 	"io"
 	"net/http"
-	"path/filepath"
 	"strings"
+
+	"mockserver/internal/logging"
+
+	"github.com/gorilla/mux"
 )
 
 const (
@@ -18,6 +20,12 @@ const (
 	internalPathPrefix = "/_mockserver"
 )
 
+// Log page templates, embedded so the server does not depend on the working
+// directory.
+//
+//go:embed templates/log
+var logTemplates embed.FS
+
 // registerInternalHandlers adds any internal handlers, such as healthcheck
 // endpoints and fallback handling.
 func (s *Server) registerInternalHandlers(ctx context.Context) {
@@ -26,12 +34,56 @@ func (s *Server) registerInternalHandlers(ctx context.Context) {
 	// Healthcheck endpoint
 	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/health", healthcheckHandler)
 
+	// Operation coverage endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/coverage", s.coverageHandler)
+
+	// Call expectation endpoints
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/expectations", s.expectationsHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodPost}, internalPathPrefix+"/expectations", s.expectationCreateHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodDelete}, internalPathPrefix+"/expectations", s.expectationsClearHandler)
+
+	// Call expectation verification endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/expectations/verify", s.expectationsVerifyHandler)
+
 	// HTTP log index endpoint
 	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/log", s.httpFileIndexHandler)
 
+	// HTTP log HAR endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/log.har", s.httpFileHARHandler)
+
+	// HTTP log operation HAR endpoint, registered before the operation
+	// endpoint as its path would otherwise also match
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/log/{operationId}.har", s.httpOperationHARHandler)
+
 	// HTTP log operation endpoint
 	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/log/{operationId}", s.httpOperationHandler)
 
+	// Integration delivery outcome endpoints
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/outcomes", s.outcomesHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodPut}, internalPathPrefix+"/outcomes", s.outcomeSetHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodDelete}, internalPathPrefix+"/outcomes", s.outcomesClearHandler)
+
+	// Request journal endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/requests", s.requestsHandler)
+
+	// State export endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/state", s.stateHandler)
+
+	// State restore endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodPut}, internalPathPrefix+"/state", s.stateRestoreHandler)
+
+	// State reset endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodPost}, internalPathPrefix+"/state/reset", s.stateResetHandler)
+
+	// Response stub endpoints
+	s.RegisterHandlerFunc(ctx, []string{http.MethodGet}, internalPathPrefix+"/stubs", s.stubsHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodPost}, internalPathPrefix+"/stubs", s.stubCreateHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodDelete}, internalPathPrefix+"/stubs", s.stubsClearHandler)
+	s.RegisterHandlerFunc(ctx, []string{http.MethodDelete}, internalPathPrefix+"/stubs/{stubId}", s.stubDeleteHandler)
+
+	// Outbound webhook event endpoint
+	s.RegisterHandlerFunc(ctx, []string{http.MethodPost}, internalPathPrefix+"/webhooks", s.webhookSendHandler)
+
 	// Default all other requests to 404 Not Found
 	s.RegisterHandlerFunc(ctx, []string{}, "/", rootHandler)
 }
@@ -86,9 +138,10 @@ func (s *Server) httpFileIndexHandler(w http.ResponseWriter, _ *http.Request) {
 	tmpl.Funcs(template.FuncMap{
 		"mod": func(i, j int) bool { return i%j == 0 },
 	})
-	_, err = tmpl.ParseFiles(
-		filepath.Join("internal", "server", "templates", "log", "style.css.tmpl"),
-		filepath.Join("internal", "server", "templates", "log", "index.html.tmpl"),
+	_, err = tmpl.ParseFS(
+		logTemplates,
+		"templates/log/style.css.tmpl",
+		"templates/log/index.html.tmpl",
 	)
 
 	if err != nil {
@@ -123,7 +176,7 @@ func (s *Server) httpFileIndexHandler(w http.ResponseWriter, _ *http.Request) {
 // httpOperationHandler returns a HTML page for HTTP request and response log files
 // written to _debug.
 func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request) {
-	operationId := req.PathValue("operationId")
+	operationId := mux.Vars(req)["operationId"]
 
 	if operationId == "" {
 		http.Error(w, "operation logs not found", http.StatusNotFound)
@@ -133,6 +186,12 @@ func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request)
 
 	operation, err := s.httpFileDir.Operation(operationId)
 
+	if logging.IsEvicted(err) {
+		http.Error(w, "operation logs not found", http.StatusNotFound)
+
+		return
+	}
+
 	if err != nil {
 		http.Error(
 			w,
@@ -185,11 +244,16 @@ func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request)
 		RequestPath:   operation.Path(),
 	}
 
-	for i := range operation.CallCount() {
-		call := i + 1
+	for _, oasCall := range operation.Calls() {
+		call := oasCall.Call()
 
 		callReqRaw, err := operation.RawRequest(call)
 
+		// Skip calls evicted since the operation was read.
+		if logging.IsEvicted(err) {
+			continue
+		}
+
 		if err != nil {
 			http.Error(
 				w,
@@ -202,6 +266,10 @@ func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request)
 
 		callReq, err := operation.Request(call)
 
+		if logging.IsEvicted(err) {
+			continue
+		}
+
 		if err != nil {
 			http.Error(
 				w,
@@ -227,6 +295,10 @@ func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request)
 
 		callRespRaw, err := operation.RawResponse(call)
 
+		if logging.IsEvicted(err) {
+			continue
+		}
+
 		if err != nil {
 			http.Error(
 				w,
@@ -239,6 +311,10 @@ func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request)
 
 		callResp, err := operation.Response(call)
 
+		if logging.IsEvicted(err) {
+			continue
+		}
+
 		if err != nil {
 			http.Error(
 				w,
@@ -302,9 +378,10 @@ func (s *Server) httpOperationHandler(w http.ResponseWriter, req *http.Request)
 	tmpl.Funcs(template.FuncMap{
 		"mod": func(i, j int) bool { return i%j == 0 },
 	})
-	_, err = tmpl.ParseFiles(
-		filepath.Join("internal", "server", "templates", "log", "style.css.tmpl"),
-		filepath.Join("internal", "server", "templates", "log", "operation.html.tmpl"),
+	_, err = tmpl.ParseFS(
+		logTemplates,
+		"templates/log/style.css.tmpl",
+		"templates/log/operation.html.tmpl",
 	)
 
 	if err != nil {
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"net/http"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/state"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Default number of layouts per list page.
+	defaultLayoutsLimit = 10
+)
+
+// layoutsHandler lists the layouts of the authenticated environment, filtered
+// by the query parameter and sorted by the orderBy and orderDirection
+// parameters.
+func (s *Server) layoutsHandler(w http.ResponseWriter, req *http.Request) {
+	environment, err := s.apiEnvironment(req)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	options := state.LayoutListOptions{
+		OrderBy:        components.LayoutResponseDtoSortField(req.URL.Query().Get("orderBy")),
+		OrderDirection: components.DirectionEnum(req.URL.Query().Get("orderDirection")),
+		Query:          req.URL.Query().Get("query"),
+	}
+
+	switch options.OrderBy {
+	case "", components.LayoutResponseDtoSortFieldCreatedAt, components.LayoutResponseDtoSortFieldName, components.LayoutResponseDtoSortFieldUpdatedAt:
+	default:
+		s.writeAPIError(w, req, newValidationError("orderBy", "orderBy must be one of the following values: createdAt, updatedAt, name"))
+
+		return
+	}
+
+	switch options.OrderDirection {
+	case "", components.DirectionEnumAsc, components.DirectionEnumDesc:
+	default:
+		s.writeAPIError(w, req, newValidationError("orderDirection", "orderDirection must be one of the following values: ASC, DESC"))
+
+		return
+	}
+
+	options.Limit, err = queryInt(req, "limit", defaultLayoutsLimit)
+
+	if err == nil {
+		options.Offset, err = queryInt(req, "offset", 0)
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	layouts, total := state.ListLayouts(environment, options)
+
+	writeAPIResponse(w, http.StatusOK, components.ListLayoutResponseDto{
+		Layouts:    layouts,
+		TotalCount: float64(total),
+	})
+}
+
+// layoutHandler returns a layout of the authenticated environment.
+func (s *Server) layoutHandler(w http.ResponseWriter, req *http.Request) {
+	environment, err := s.apiEnvironment(req)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	id := mux.Vars(req)["layoutId"]
+	index := environment.LayoutIndex(id)
+
+	if index < 0 {
+		s.writeAPIError(w, req, newAPIError(http.StatusNotFound, "Layout %s not found", id))
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, environment.Layouts[index])
+}
+
+// layoutCreateHandler adds a layout to the authenticated environment.
+func (s *Server) layoutCreateHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.CreateLayoutDto
+
+	err := decodeAPIRequest(req, &body)
+
+	switch {
+	case err != nil:
+	case body.LayoutID == "":
+		err = newValidationError("layoutId", "layoutId should not be empty")
+	case body.Name == "":
+		err = newValidationError("name", "name should not be empty")
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result *components.LayoutResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result, err = state.CreateLayout(environment, body, s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusCreated, result)
+}
+
+// layoutUpdateHandler replaces the name and control values of a layout of the
+// authenticated environment.
+func (s *Server) layoutUpdateHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.UpdateLayoutDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err == nil && body.Name == "" {
+		err = newValidationError("name", "name should not be empty")
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result *components.LayoutResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result, err = state.UpdateLayout(environment, mux.Vars(req)["layoutId"], body, s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
+
+// layoutDeleteHandler removes a layout of the authenticated environment that
+// is not used by any email step.
+func (s *Server) layoutDeleteHandler(w http.ResponseWriter, req *http.Request) {
+	err := s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		return state.DeleteLayout(environment, mux.Vars(req)["layoutId"])
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// layoutDuplicateHandler copies a layout of the authenticated environment
+// under a new layout identifier.
+func (s *Server) layoutDuplicateHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.DuplicateLayoutDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result *components.LayoutResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result, err = state.DuplicateLayout(environment, mux.Vars(req)["layoutId"], body.Name, s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusCreated, result)
+}
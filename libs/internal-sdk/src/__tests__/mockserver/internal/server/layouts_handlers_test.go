@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestLayoutHandlers(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v2/layouts", "dev-key", `{"layoutId":"alert","name":"Alert"}`)
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	var created components.LayoutResponseDto
+
+	decodeAPIResponse(t, body, &created)
+
+	if created.IsDefault || created.Controls.Values.Email == nil {
+		t.Errorf("expected non-default layout with email content, got: %+v", created)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPut, "/v2/layouts/alert", "dev-key", `{"name":"Alerts","controlValues":{"email":{"content":"<b>{{content}}</b>","editorType":"html"}}}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodGet, "/v2/layouts/"+created.ID, "dev-key", "")
+
+	var got components.LayoutResponseDto
+
+	decodeAPIResponse(t, body, &got)
+
+	if status != http.StatusOK || got.Name != "Alerts" || got.Controls.Values.Email.Content != "<b>{{content}}</b>" {
+		t.Errorf("expected updated layout, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v2/layouts/alert/duplicate", "dev-key", "")
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodGet, "/v2/layouts?query=alert&orderBy=name&orderDirection=ASC", "dev-key", "")
+
+	var list components.ListLayoutResponseDto
+
+	decodeAPIResponse(t, body, &list)
+
+	if status != http.StatusOK || list.TotalCount != 2 || list.Layouts[0].Name != "Alerts" || list.Layouts[1].Name != "Alerts (copy)" {
+		t.Errorf("expected 2 sorted layouts, got: %d %s", status, body)
+	}
+
+	for _, test := range []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{method: http.MethodDelete, path: "/v2/layouts/alert", status: http.StatusNoContent},
+		{method: http.MethodDelete, path: "/v2/layouts/default-layout", status: http.StatusConflict},
+		{method: http.MethodGet, path: "/v2/layouts/alert", status: http.StatusNotFound},
+		{method: http.MethodPost, path: "/v2/layouts", body: `{"layoutId":"alert-copy","name":"Copy"}`, status: http.StatusConflict},
+		{method: http.MethodPost, path: "/v2/layouts", body: `{"name":"Nameless"}`, status: http.StatusUnprocessableEntity},
+		{method: http.MethodGet, path: "/v2/layouts?orderBy=unknown", status: http.StatusUnprocessableEntity},
+		{method: http.MethodGet, path: "/v2/layouts?limit=-1", status: http.StatusUnprocessableEntity},
+	} {
+		status, body := apiCall(t, ts, test.method, test.path, "dev-key", test.body)
+
+		if status != test.status {
+			t.Errorf("%s %s: expected status %d, got: %d %s", test.method, test.path, test.status, status, body)
+		}
+	}
+}
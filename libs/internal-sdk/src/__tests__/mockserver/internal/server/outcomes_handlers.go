@@ -0,0 +1,72 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"mockserver/internal/state"
+)
+
+// outcomesHandler returns the delivery outcomes configured for integrations of
+// all environments.
+func (s *Server) outcomesHandler(w http.ResponseWriter, _ *http.Request) {
+	type outcomesModel struct {
+		Outcomes []state.IntegrationOutcome `json:"outcomes"`
+	}
+
+	result := outcomesModel{
+		Outcomes: []state.IntegrationOutcome{},
+	}
+
+	for _, environment := range s.state.Environments() {
+		result.Outcomes = append(result.Outcomes, environment.Outcomes...)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// outcomeSetHandler configures the delivery outcome of the integration in the
+// request body, replacing its previous outcome.
+func (s *Server) outcomeSetHandler(w http.ResponseWriter, req *http.Request) {
+	var outcome state.IntegrationOutcome
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&outcome)
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("outcome request body error: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	err = s.state.Update(func(environments []state.EnvironmentState) error {
+		return state.SetIntegrationOutcome(environments, outcome)
+	})
+
+	switch {
+	case errors.Is(err, state.ErrNotFound):
+		http.Error(w, fmt.Sprintf("outcome error: %s", err), http.StatusNotFound)
+	case err != nil:
+		http.Error(w, fmt.Sprintf("invalid outcome: %s", err), http.StatusBadRequest)
+	default:
+		writeJSON(w, http.StatusOK, outcome)
+	}
+}
+
+// outcomesClearHandler removes the delivery outcomes of all integrations, so
+// all deliveries succeed.
+func (s *Server) outcomesClearHandler(w http.ResponseWriter, _ *http.Request) {
+	_ = s.state.Update(func(environments []state.EnvironmentState) error {
+		for i := range environments {
+			environments[i].Outcomes = nil
+		}
+
+		return nil
+	})
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestOutcomeHandlers(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPut, "/_mockserver/outcomes", "", `{"integrationId":"int-dev-email","outcome":"permanent_failure","message":"Invalid API key"}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodGet, "/_mockserver/outcomes", "", "")
+
+	if status != http.StatusOK || !strings.Contains(string(body), `"outcome": "permanent_failure"`) {
+		t.Errorf("expected configured outcome, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"alice"}`)
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	dev := s.state.Environments()[0]
+	jobs := dev.Notifications[len(dev.Notifications)-1].Jobs
+
+	for _, job := range jobs {
+		if job.Type != components.ActivityNotificationJobResponseDtoTypeEmail {
+			continue
+		}
+
+		if job.Status != "failed" || job.ExecutionDetails[0].Detail != "Provider sendgrid returned a permanent error: Invalid API key" {
+			t.Errorf("expected failed email job, got: %+v", job)
+		}
+	}
+
+	status, _ = apiCall(t, ts, http.MethodDelete, "/_mockserver/outcomes", "", "")
+
+	if status != http.StatusNoContent || len(s.state.Environments()[0].Outcomes) != 0 {
+		t.Errorf("expected outcomes to be cleared, got: %d %+v", status, s.state.Environments()[0].Outcomes)
+	}
+
+	for _, test := range []struct {
+		status int
+		body   string
+	}{
+		{status: http.StatusNotFound, body: `{"integrationId":"int-unknown","outcome":"delay","delayMs":100}`},
+		{status: http.StatusBadRequest, body: `{"integrationId":"int-dev-email","outcome":"timeout"}`},
+		{status: http.StatusBadRequest, body: `{"integrationId":"int-dev-email","outcome":"delay","delay":"1s"}`},
+	} {
+		status, body := apiCall(t, ts, http.MethodPut, "/_mockserver/outcomes", "", test.body)
+
+		if status != test.status {
+			t.Errorf("%s: expected status %d, got: %d %s", test.body, test.status, status, body)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"mockserver/internal/logging"
+)
+
+// requestsHandler returns the logged operation calls matching the query
+// parameter filters as JSON, ordered by request time.
+func (s *Server) requestsHandler(w http.ResponseWriter, req *http.Request) {
+	filter, err := newJournalFilter(req.URL.Query())
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("requests filter error: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	entries, err := s.journalEntries()
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("operation log error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	type requestsModel struct {
+		Requests []*logging.JournalEntry `json:"requests"`
+	}
+
+	result := requestsModel{
+		Requests: []*logging.JournalEntry{},
+	}
+
+	for _, entry := range entries {
+		if filter(entry) {
+			result.Requests = append(result.Requests, entry)
+		}
+	}
+
+	body, err := json.MarshalIndent(result, "", "  ")
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("requests encoding error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// journalEntries returns all logged operation calls, in the order the requests
+// were received. Calls evicted while reading are skipped.
+func (s *Server) journalEntries() ([]*logging.JournalEntry, error) {
+	operations, err := s.httpFileDir.Operations()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*logging.JournalEntry
+
+	for _, operation := range operations {
+		for _, call := range operation.Calls() {
+			entry, err := logging.NewJournalEntry(call)
+
+			if logging.IsEvicted(err) {
+				continue
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, entry)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Sequence < result[j].Sequence
+	})
+
+	return result, nil
+}
+
+// newJournalFilter returns a journal entry filter from the query parameters:
+//
+//   - operationId: comma-separated OAS operation identifiers
+//   - method: comma-separated HTTP methods
+//   - pathPrefix: request path prefix
+//   - since: only requests received after this RFC 3339 time
+//   - status: comma-separated status classes or codes, such as 5xx or 404
+//   - namespace: test namespace
+func newJournalFilter(query url.Values) (func(*logging.JournalEntry) bool, error) {
+	operationIds := splitQueryValues(query, "operationId")
+	methods := upperValues(splitQueryValues(query, "method"))
+	pathPrefix := query.Get("pathPrefix")
+	namespace := query.Get("namespace")
+
+	statusFilter, err := newStatusFilter(splitQueryValues(query, "status"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var since time.Time
+
+	if value := query.Get("since"); value != "" {
+		since, err = time.Parse(time.RFC3339Nano, value)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid since %q, expected RFC 3339 time: %w", value, err)
+		}
+	}
+
+	result := func(entry *logging.JournalEntry) bool {
+		if len(operationIds) > 0 && !slices.Contains(operationIds, entry.OperationID) {
+			return false
+		}
+
+		if len(methods) > 0 && !slices.Contains(methods, entry.Request.Method) {
+			return false
+		}
+
+		if pathPrefix != "" && !strings.HasPrefix(entry.Request.Path, pathPrefix) {
+			return false
+		}
+
+		if !since.IsZero() && !entry.Time.After(since) {
+			return false
+		}
+
+		if !statusFilter(entry.Response.Status) {
+			return false
+		}
+
+		if namespace != "" && entry.Namespace != namespace {
+			return false
+		}
+
+		return true
+	}
+
+	return result, nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"mockserver/internal/logging"
+)
+
+func TestNewJournalFilter(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	entry := func(operationId string, method string, path string, status int, namespace string, offset time.Duration) *logging.JournalEntry {
+		return &logging.JournalEntry{
+			OperationID: operationId,
+			Namespace:   namespace,
+			Time:        start.Add(offset),
+			Request:     logging.JournalRequest{Method: method, Path: path},
+			Response:    logging.JournalResponse{Status: status},
+		}
+	}
+
+	entries := []*logging.JournalEntry{
+		entry("EventsController_trigger", http.MethodPost, "/v1/events/trigger", http.StatusCreated, "test-1", 0),
+		entry("EventsController_cancel", http.MethodDelete, "/v1/events/trigger/tx", http.StatusOK, "test-2", time.Second),
+		entry("SubscribersController_getSubscriber", http.MethodGet, "/v2/subscribers/bob", http.StatusNotFound, "test-1", 2*time.Second),
+		entry("SubscribersController_getSubscriber", http.MethodGet, "/v2/subscribers/alice", http.StatusInternalServerError, "", 3*time.Second),
+	}
+
+	for _, test := range []struct {
+		name     string
+		query    url.Values
+		expected []int
+	}{
+		{name: "no filters", query: url.Values{}, expected: []int{0, 1, 2, 3}},
+		{name: "operation", query: url.Values{"operationId": {"EventsController_trigger,EventsController_cancel"}}, expected: []int{0, 1}},
+		{name: "method", query: url.Values{"method": {"get", "delete"}}, expected: []int{1, 2, 3}},
+		{name: "path prefix", query: url.Values{"pathPrefix": {"/v1/events/trigger"}}, expected: []int{0, 1}},
+		{name: "path prefix without match", query: url.Values{"pathPrefix": {"/v1/events/trigger/other"}}, expected: nil},
+		{name: "since excludes equal time", query: url.Values{"since": {start.Add(time.Second).Format(time.RFC3339Nano)}}, expected: []int{2, 3}},
+		{name: "since with offset", query: url.Values{"since": {"2026-01-01T13:00:01.5+01:00"}}, expected: []int{2, 3}},
+		{name: "status class", query: url.Values{"status": {"2xx"}}, expected: []int{0, 1}},
+		{name: "status code", query: url.Values{"status": {"404"}}, expected: []int{2}},
+		{name: "status class and code", query: url.Values{"status": {"5XX,201"}}, expected: []int{0, 3}},
+		{name: "namespace", query: url.Values{"namespace": {"test-1"}}, expected: []int{0, 2}},
+		{name: "combined", query: url.Values{"namespace": {"test-1"}, "status": {"4xx"}, "method": {"GET"}}, expected: []int{2}},
+	} {
+		filter, err := newJournalFilter(test.query)
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+
+			continue
+		}
+
+		var got []int
+
+		for i, e := range entries {
+			if filter(e) {
+				got = append(got, i)
+			}
+		}
+
+		if !slices.Equal(got, test.expected) {
+			t.Errorf("%s: expected entries %v, got: %v", test.name, test.expected, got)
+		}
+	}
+
+	for _, test := range []struct {
+		query    url.Values
+		expected string
+	}{
+		{query: url.Values{"since": {"yesterday"}}, expected: `invalid since "yesterday"`},
+		{query: url.Values{"status": {"9xx"}}, expected: `invalid status class "9xx"`},
+		{query: url.Values{"status": {"600"}}, expected: `invalid status "600"`},
+	} {
+		_, err := newJournalFilter(test.query)
+
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("%s: expected error %q, got: %v", test.query.Encode(), test.expected, err)
+		}
+	}
+}
+
+func TestRequestsHandler(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"alice"}`)
+	apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "wrong-key", `{"name":"welcome","to":"alice"}`)
+
+	status, body := apiCall(t, ts, http.MethodGet, "/_mockserver/requests?status=2xx", "", "")
+
+	if status != http.StatusOK || strings.Count(string(body), `"operationId"`) != 1 || !strings.Contains(string(body), `"status": 201`) {
+		t.Errorf("expected 1 successful trigger call, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodGet, "/_mockserver/requests?since=yesterday", "", "")
+
+	if status != http.StatusBadRequest || !strings.Contains(string(body), "requests filter error") {
+		t.Errorf("expected status 400 with filter error, got: %d %s", status, body)
+	}
+}
@@ -1,5 +1,3 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package server
 
 import (
@@ -7,9 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"mockserver/internal/coverage"
+	"mockserver/internal/expectation"
 	"mockserver/internal/logging"
+	"mockserver/internal/state"
+	"mockserver/internal/stub"
 	"mockserver/internal/tracking"
+	"mockserver/internal/webhook"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -26,9 +30,38 @@ type Server struct {
 	// Address for server listening.
 	address string
 
+	// Clock of emulated API operations, such as for timestamps of stored
+	// resources. By default, this is [time.Now].
+	clock func() time.Time
+
+	// Path to the operation coverage report file written on shutdown. By
+	// default, this is coverage.json in the HTTP file directory.
+	coverageFilePath string
+
+	// Call expectations registered by tests.
+	expectations *expectation.Registry
+
 	// Directory for raw HTTP request and response files.
 	httpFileDir *logging.HTTPFileDirectory
 
+	// Options for the directory for raw HTTP request and response files, such
+	// as retention limits.
+	httpFileDirOpts []logging.HTTPFileDirectoryOption
+
+	// Path to the directory for raw HTTP request and response files. By
+	// default, this is _debug in the working directory.
+	httpFileDirPath string
+
+	// Whether raw HTTP request and response files of a previous run are kept
+	// instead of removed on start.
+	httpFileKeepPrevious bool
+
+	// Stream of HTTP request and response pairs for live subscribers.
+	httpStream *logging.HTTPStream
+
+	// Size in bytes at which streamed bodies are truncated.
+	httpStreamMaxBodySize int
+
 	// Logger implementation.
 	logger *slog.Logger
 
@@ -41,16 +74,36 @@ type Server struct {
 	server *http.Server
 
 	requestTracker *tracking.RequestTracker
+
+	// In-memory state, such as seeded environments and their resources.
+	state *state.Store
+
+	// Response stubs registered by tests, which override generated handlers.
+	stubs *stub.Registry
+
+	// Secret outbound webhook events are signed with.
+	webhookSecret string
+
+	// URL outbound webhook events are posted to. By default, no events are
+	// posted.
+	webhookURL string
+
+	// Dispatcher of outbound webhook events, or nil without a webhook URL.
+	webhooks *webhook.Dispatcher
 }
 
 // NewServer creates a new Server instance.
 func NewServer(ctx context.Context, opts ...ServerOption) (*Server, error) {
 	// Initialize with defaults.
 	result := &Server{
-		address:        DefaultAddress,
-		logger:         slog.Default(),
-		mux:            mux.NewRouter(),
-		requestTracker: tracking.New(),
+		address:               DefaultAddress,
+		clock:                 time.Now,
+		httpStreamMaxBodySize: logging.DefaultHTTPStreamMaxBodySize,
+		logger:                slog.Default(),
+		mux:                   mux.NewRouter(),
+		requestTracker:        tracking.New(),
+		state:                 state.New(),
+		stubs:                 stub.NewRegistry(),
 	}
 
 	// Customize based on ServerOption.
@@ -62,27 +115,42 @@ func NewServer(ctx context.Context, opts ...ServerOption) (*Server, error) {
 		}
 	}
 
+	result.expectations = expectation.NewRegistry()
+
+	if result.webhookURL != "" {
+		result.webhooks = webhook.NewDispatcher(result.webhookURL, webhook.WithLogger(result.logger), webhook.WithSecret(result.webhookSecret))
+	}
+
+	result.httpStream = logging.NewHTTPStream(result.httpStreamMaxBodySize)
+
 	result.server = &http.Server{
 		Addr:     result.address,
-		Handler:  logging.HTTPLoggerHandler(result.logger, result.mux),
+		Handler:  result.streamHandler(logging.HTTPLoggerHandler(result.logger, result.httpStream, result.expectationHandler(result.stubHandler(result.mux)))),
 		ErrorLog: slog.NewLogLogger(result.logger.Handler(), slog.LevelError),
 	}
 
-	httpFileDir, err := logging.NewHTTPFileDirectory("")
+	httpFileDir, err := logging.NewHTTPFileDirectory(result.httpFileDirPath, result.httpFileDirOpts...)
 
 	if err != nil {
 		return result, err
 	}
 
-	err = httpFileDir.Clean()
+	if !result.httpFileKeepPrevious {
+		err = httpFileDir.Clean()
 
-	if err != nil {
-		return result, err
+		if err != nil {
+			return result, err
+		}
 	}
 
 	result.httpFileDir = httpFileDir
 
+	if result.coverageFilePath == "" {
+		result.coverageFilePath = filepath.Join(httpFileDir.Path(), logging.DefaultCoverageFilename)
+	}
+
 	result.registerGeneratedHandlers(ctx)
+	result.registerAPIHandlers(ctx)
 	result.registerInternalHandlers(ctx)
 
 	return result, err
@@ -93,6 +161,13 @@ func (s *Server) Address() string {
 	return "http://localhost" + s.address
 }
 
+// Handler returns the root HTTP handler, including logging. This allows the
+// server to be embedded in another HTTP server, such as [httptest.Server],
+// instead of calling Serve.
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
+}
+
 // RegisterHandlerFunc adds a new HTTP handler function for the given methods and path.
 func (s *Server) RegisterHandlerFunc(ctx context.Context, methods []string, path string, handlerFunc http.HandlerFunc) {
 	s.logger.DebugContext(ctx, fmt.Sprintf("registering handler for %s %s", strings.Join(methods, ", "), path))
@@ -121,6 +196,9 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.WarnContext(ctx, "shutting down server")
 	s.server.SetKeepAlivesEnabled(false)
 
+	// End long-lived stream responses, which would otherwise block shutdown.
+	s.httpStream.Close()
+
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
@@ -130,5 +208,24 @@ func (s *Server) Shutdown(ctx context.Context) error {
 		return fmt.Errorf("error shutting down server: %w", err)
 	}
 
+	// Deliver webhook events of the last requests before exiting.
+	if s.webhooks != nil {
+		s.webhooks.Wait()
+	}
+
+	report, err := coverage.NewReport(s.httpFileDir)
+
+	if err != nil {
+		return fmt.Errorf("error creating coverage report: %w", err)
+	}
+
+	err = report.WriteFile(s.coverageFilePath)
+
+	if err != nil {
+		return err
+	}
+
+	s.logger.InfoContext(ctx, "wrote coverage report to "+s.coverageFilePath)
+
 	return nil
 }
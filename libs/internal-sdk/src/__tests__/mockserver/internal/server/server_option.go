@@ -1,9 +1,12 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package server
 
 import (
+	"fmt"
 	"log/slog"
+	"time"
+
+	"mockserver/internal/logging"
+	"mockserver/internal/state"
 )
 
 // ServerOption is a function which modifies the Server.
@@ -19,6 +22,89 @@ func WithAddress(address string) ServerOption {
 	}
 }
 
+// WithClock sets the clock of emulated API operations for a Server, such as
+// for timestamps of stored resources. By default, the clock is [time.Now].
+func WithClock(clock func() time.Time) ServerOption {
+	return func(s *Server) error {
+		s.clock = clock
+
+		return nil
+	}
+}
+
+// WithCoverageFile sets the file the operation coverage report is written to
+// when a Server shuts down. By default, the file is coverage.json in the HTTP
+// file directory.
+func WithCoverageFile(path string) ServerOption {
+	return func(s *Server) error {
+		s.coverageFilePath = path
+
+		return nil
+	}
+}
+
+// WithHTTPFileDirectory sets the directory for raw HTTP request and response
+// files for a Server. By default, the directory is _debug in the working
+// directory.
+func WithHTTPFileDirectory(path string) ServerOption {
+	return func(s *Server) error {
+		s.httpFileDirPath = path
+
+		return nil
+	}
+}
+
+// WithHTTPFileGzip enables gzip compression of raw HTTP request and response
+// files for a Server. By default, files are not compressed.
+func WithHTTPFileGzip(enabled bool) ServerOption {
+	return func(s *Server) error {
+		s.httpFileDirOpts = append(s.httpFileDirOpts, logging.WithHTTPFileGzip(enabled))
+
+		return nil
+	}
+}
+
+// WithHTTPFileKeepPrevious keeps raw HTTP request and response files of a
+// previous run for a Server, continuing call numbering after them. By default,
+// the directory is cleaned on start.
+func WithHTTPFileKeepPrevious(keep bool) ServerOption {
+	return func(s *Server) error {
+		s.httpFileKeepPrevious = keep
+
+		return nil
+	}
+}
+
+// WithHTTPFileMaxCalls sets the maximum number of calls with raw HTTP request
+// and response files kept per operation for a Server. By default, all calls
+// are kept.
+func WithHTTPFileMaxCalls(maxCalls int64) ServerOption {
+	return func(s *Server) error {
+		if maxCalls < 0 {
+			return fmt.Errorf("invalid HTTP file maximum calls (%d): must not be negative", maxCalls)
+		}
+
+		s.httpFileDirOpts = append(s.httpFileDirOpts, logging.WithHTTPFileMaxCalls(maxCalls))
+
+		return nil
+	}
+}
+
+// WithHTTPFileMaxSize sets the maximum total size in bytes of raw HTTP request
+// and response files kept for a Server, evicting the oldest calls first. By
+// default, the size is unlimited.
+func WithHTTPFileMaxSize(maxSize int64) ServerOption {
+	return func(s *Server) error {
+		if maxSize < 0 {
+			return fmt.Errorf("invalid HTTP file maximum size (%d): must not be negative", maxSize)
+		}
+
+		s.httpFileDirOpts = append(s.httpFileDirOpts, logging.WithHTTPFileMaxSize(maxSize))
+
+		return nil
+	}
+}
+
 // WithLogger sets the logger implementation for a Server. By default, the
 // server logger is [slog.Default].
 func WithLogger(logger *slog.Logger) ServerOption {
@@ -28,3 +114,57 @@ func WithLogger(logger *slog.Logger) ServerOption {
 		return nil
 	}
 }
+
+// WithSeed loads the seed file at path into the Server state before it starts
+// serving. Files with a .yaml or .yml extension are decoded as YAML, all others
+// as JSON. By default, the server state is empty.
+func WithSeed(path string) ServerOption {
+	return func(s *Server) error {
+		seed, err := state.ReadSeedFile(path)
+
+		if err != nil {
+			return err
+		}
+
+		s.state.Load(seed)
+
+		return nil
+	}
+}
+
+// WithStreamMaxBodySize sets the size in bytes at which request and response
+// bodies of streamed events are truncated for a Server. Zero disables
+// truncation. By default, bodies are truncated at 4096 bytes.
+func WithStreamMaxBodySize(maxBodySize int) ServerOption {
+	return func(s *Server) error {
+		if maxBodySize < 0 {
+			return fmt.Errorf("invalid stream maximum body size (%d): must not be negative", maxBodySize)
+		}
+
+		s.httpStreamMaxBodySize = maxBodySize
+
+		return nil
+	}
+}
+
+// WithWebhookSecret sets the secret outbound webhook events are signed with
+// for a Server, via the novu-signature header. By default, events are not
+// signed.
+func WithWebhookSecret(secret string) ServerOption {
+	return func(s *Server) error {
+		s.webhookSecret = secret
+
+		return nil
+	}
+}
+
+// WithWebhookURL sets the URL outbound webhook events, such as sent messages of
+// emulated triggers, are posted to for a Server. By default, no events are
+// posted.
+func WithWebhookURL(url string) ServerOption {
+	return func(s *Server) error {
+		s.webhookURL = url
+
+		return nil
+	}
+}
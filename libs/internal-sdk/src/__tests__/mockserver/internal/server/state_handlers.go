@@ -0,0 +1,102 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"mockserver/internal/sdk/utils"
+	"mockserver/internal/state"
+)
+
+// stateHandler returns the complete in-memory state as a versioned JSON
+// snapshot. The snapshot is encoded via [utils.MarshalJSON] like API
+// responses, so additional properties of the API types are kept.
+func (s *Server) stateHandler(w http.ResponseWriter, _ *http.Request) {
+	body, err := utils.MarshalJSON(s.state.Snapshot(), "", true)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("state export error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	var indented bytes.Buffer
+
+	err = json.Indent(&indented, body, "", "  ")
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("state export error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = indented.WriteTo(w)
+}
+
+// stateRestoreHandler replaces the in-memory state with a JSON snapshot
+// previously returned by stateHandler.
+func (s *Server) stateRestoreHandler(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("state restore request body error: %s", err),
+			http.StatusBadRequest,
+		)
+
+		return
+	}
+
+	snapshot, err := state.ParseSnapshot(body)
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("state restore error: %s", err),
+			http.StatusBadRequest,
+		)
+
+		return
+	}
+
+	s.state.Restore(snapshot)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// stateResetHandler clears the in-memory state, HTTP logs, call expectations,
+// response stubs and request tracking counts.
+func (s *Server) stateResetHandler(w http.ResponseWriter, _ *http.Request) {
+	err := s.httpFileDir.Clean()
+
+	if err != nil {
+		http.Error(
+			w,
+			fmt.Sprintf("state reset log error: %s", err),
+			http.StatusInternalServerError,
+		)
+
+		return
+	}
+
+	s.expectations.Clear("")
+	s.requestTracker.Reset()
+	s.state.Reset()
+	s.stubs.Clear("")
+
+	w.WriteHeader(http.StatusNoContent)
+}
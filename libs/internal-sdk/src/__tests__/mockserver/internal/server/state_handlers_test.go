@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mockserver/internal/state"
+)
+
+func TestStateHandlersRoundTrip(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	// Additional properties and unset fields with a default must survive the
+	// round trip unchanged.
+	err := s.state.Update(func(environments []state.EnvironmentState) error {
+		controlValues := environments[0].Workflows[0].Steps[1].EmailStepResponseDto.ControlValues
+		controlValues.AdditionalProperties = map[string]any{"preheader": "Hi there"}
+		controlValues.EditorType = nil
+		environments[0].Workflows[0].Active = nil
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	status, exported := apiCall(t, ts, http.MethodGet, "/_mockserver/state", "", "")
+
+	if status != http.StatusOK || !strings.Contains(string(exported), `"preheader": "Hi there"`) {
+		t.Fatalf("expected snapshot with additional properties, got: %d %s", status, exported)
+	}
+
+	status, body := apiCall(t, ts, http.MethodPost, "/_mockserver/state/reset", "", "")
+
+	if status != http.StatusNoContent || len(s.state.Environments()) != 0 {
+		t.Fatalf("expected empty state after reset, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPut, "/_mockserver/state", "", string(exported))
+
+	if status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got: %d %s", status, body)
+	}
+
+	status, restored := apiCall(t, ts, http.MethodGet, "/_mockserver/state", "", "")
+
+	if status != http.StatusOK || string(restored) != string(exported) {
+		t.Errorf("expected restored snapshot to equal the export, got:\n%s\nexpected:\n%s", restored, exported)
+	}
+}
+
+func TestStateRestoreHandlerErrors(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	for _, test := range []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{name: "missing version", body: `{"environments":[]}`, expected: "missing version"},
+		{name: "old version", body: `{"version":1,"environments":[]}`, expected: "unsupported version 1"},
+		{name: "unknown field", body: `{"version":1,"state":{}}`, expected: `unknown field "state"`},
+	} {
+		status, body := apiCall(t, ts, http.MethodPut, "/_mockserver/state", "", test.body)
+
+		if status != http.StatusBadRequest || !strings.Contains(string(body), test.expected) {
+			t.Errorf("%s: expected status 400 with %q, got: %d %s", test.name, test.expected, status, body)
+		}
+	}
+
+	if len(s.state.Environments()) != 2 {
+		t.Errorf("expected rejected snapshots to keep the state, got: %d environments", len(s.state.Environments()))
+	}
+}
+
+func TestStateResetHandler(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	for _, call := range []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{method: http.MethodPost, path: "/v1/events/trigger", body: `{"name":"welcome","to":"alice"}`},
+		{method: http.MethodPost, path: "/_mockserver/stubs", body: `{"method":"GET","path":"/v1/environments","response":{"status":200}}`},
+		{method: http.MethodPost, path: "/_mockserver/expectations", body: `{"method":"GET","path":"/v1/environments","times":{"atLeast":1}}`},
+	} {
+		status, body := apiCall(t, ts, call.method, call.path, "dev-key", call.body)
+
+		if status >= http.StatusBadRequest {
+			t.Fatalf("%s %s: unexpected status %d %s", call.method, call.path, status, body)
+		}
+	}
+
+	status, body := apiCall(t, ts, http.MethodPost, "/_mockserver/state/reset", "", "")
+
+	if status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got: %d %s", status, body)
+	}
+
+	if len(s.state.Environments()) != 0 {
+		t.Errorf("expected empty state, got: %d environments", len(s.state.Environments()))
+	}
+
+	for path, key := range map[string]string{
+		"/_mockserver/requests":     "requests",
+		"/_mockserver/stubs":        "stubs",
+		"/_mockserver/expectations": "expectations",
+	} {
+		status, body := apiCall(t, ts, http.MethodGet, path, "", "")
+
+		var result map[string][]json.RawMessage
+
+		err := json.Unmarshal(body, &result)
+
+		if status != http.StatusOK || err != nil || len(result[key]) != 0 {
+			t.Errorf("%s: expected no %s after reset, got: %d %s", path, key, status, body)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"mockserver/internal/logging"
+)
+
+const (
+	// Path of the live HTTP stream endpoint.
+	streamPath = internalPathPrefix + "/stream"
+
+	// Interval between keepalive comments on idle streams, which prevents
+	// proxies and clients from timing out.
+	streamKeepaliveInterval = 15 * time.Second
+)
+
+// streamHandler routes stream requests directly to httpStreamHandler, as the
+// next handler buffers complete responses, and all other requests to next.
+func (s *Server) streamHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != streamPath {
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		if req.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		s.httpStreamHandler(w, req)
+	})
+}
+
+// httpStreamHandler sends every HTTP request and response pair matching the
+// query parameter filters as a Server-Sent Event.
+func (s *Server) httpStreamHandler(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	filter, err := newStreamFilter(req.URL.Query())
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("stream filter error: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	events, unsubscribe := s.httpStream.Subscribe(filter)
+	defer unsubscribe()
+
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	keepalive := time.NewTicker(streamKeepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+
+			if err != nil {
+				s.logger.ErrorContext(req.Context(), fmt.Sprintf("error encoding stream event: %s", err))
+
+				continue
+			}
+
+			fmt.Fprintf(w, "id: %d\nevent: call\ndata: %s\n\n", event.ID, data)
+			flusher.Flush()
+		}
+	}
+}
+
+// newStreamFilter returns a stream event filter from the query parameters:
+//
+//   - operationId: comma-separated OAS operation identifiers
+//   - method: comma-separated HTTP methods
+//   - status: comma-separated status classes or codes, such as 5xx or 404
+//   - namespace: test namespace
+//   - internal: whether to include /_mockserver requests, false by default
+func newStreamFilter(query url.Values) (func(*logging.HTTPStreamEvent) bool, error) {
+	operationIds := splitQueryValues(query, "operationId")
+	methods := upperValues(splitQueryValues(query, "method"))
+	namespace := query.Get("namespace")
+	includeInternal := query.Get("internal") == "true"
+
+	statusFilter, err := newStatusFilter(splitQueryValues(query, "status"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := func(event *logging.HTTPStreamEvent) bool {
+		if !includeInternal && strings.HasPrefix(event.Request.URL, internalPathPrefix+"/") {
+			return false
+		}
+
+		if len(operationIds) > 0 && !slices.Contains(operationIds, event.OperationID) {
+			return false
+		}
+
+		if len(methods) > 0 && !slices.Contains(methods, event.Request.Method) {
+			return false
+		}
+
+		if !statusFilter(event.Response.Status) {
+			return false
+		}
+
+		if namespace != "" && event.Namespace != namespace {
+			return false
+		}
+
+		return true
+	}
+
+	return result, nil
+}
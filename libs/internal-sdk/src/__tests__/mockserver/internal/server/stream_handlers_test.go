@@ -0,0 +1,147 @@
+package server
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"mockserver/internal/logging"
+)
+
+func TestNewStreamFilter(t *testing.T) {
+	event := func(method string, path string, operationId string, status int, namespace string) *logging.HTTPStreamEvent {
+		return &logging.HTTPStreamEvent{
+			OperationID: operationId,
+			Namespace:   namespace,
+			Request:     logging.HTTPStreamRequest{Method: method, URL: path},
+			Response:    logging.HTTPStreamResponse{Status: status},
+		}
+	}
+
+	trigger := event(http.MethodPost, "/v1/events/trigger", "EventsController_trigger", http.StatusCreated, "test-1")
+	notFound := event(http.MethodGet, "/v2/subscribers/bob", "SubscribersController_getSubscriber", http.StatusNotFound, "")
+	internal := event(http.MethodGet, "/_mockserver/requests", "", http.StatusOK, "")
+
+	for _, test := range []struct {
+		query    string
+		expected []*logging.HTTPStreamEvent
+	}{
+		{query: "", expected: []*logging.HTTPStreamEvent{trigger, notFound}},
+		{query: "internal=true", expected: []*logging.HTTPStreamEvent{trigger, notFound, internal}},
+		{query: "operationId=EventsController_trigger,SubscribersController_getSubscriber", expected: []*logging.HTTPStreamEvent{trigger, notFound}},
+		{query: "operationId=EventsController_trigger", expected: []*logging.HTTPStreamEvent{trigger}},
+		{query: "method=get&internal=true", expected: []*logging.HTTPStreamEvent{notFound, internal}},
+		{query: "method=post&method=get", expected: []*logging.HTTPStreamEvent{trigger, notFound}},
+		{query: "status=4xx", expected: []*logging.HTTPStreamEvent{notFound}},
+		{query: "status=201,5XX", expected: []*logging.HTTPStreamEvent{trigger}},
+		{query: "namespace=test-1", expected: []*logging.HTTPStreamEvent{trigger}},
+		{query: "namespace=test-2", expected: nil},
+	} {
+		query, err := url.ParseQuery(test.query)
+
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", test.query, err)
+		}
+
+		filter, err := newStreamFilter(query)
+
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", test.query, err)
+		}
+
+		var got []*logging.HTTPStreamEvent
+
+		for _, e := range []*logging.HTTPStreamEvent{trigger, notFound, internal} {
+			if filter(e) {
+				got = append(got, e)
+			}
+		}
+
+		if len(got) != len(test.expected) {
+			t.Errorf("%q: expected %d matching events, got: %d", test.query, len(test.expected), len(got))
+
+			continue
+		}
+
+		for i := range got {
+			if got[i] != test.expected[i] {
+				t.Errorf("%q: expected event %s, got: %s", test.query, test.expected[i].Request.URL, got[i].Request.URL)
+			}
+		}
+	}
+
+	for _, query := range []string{"status=6xx", "status=2x", "status=abc", "status=99"} {
+		values, _ := url.ParseQuery(query)
+
+		if _, err := newStreamFilter(values); err == nil {
+			t.Errorf("%q: expected error, got: nil", query)
+		}
+	}
+}
+
+func TestHTTPStreamHandler(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodGet, "/_mockserver/stream?status=6xx", "", "")
+
+	if status != http.StatusBadRequest || !strings.Contains(string(body), "invalid status class") {
+		t.Errorf("expected status 400 with invalid status class, got: %d %s", status, body)
+	}
+
+	resp, err := ts.Client().Get(ts.URL + "/_mockserver/stream?operationId=EventsController_trigger")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+
+	if line, err := reader.ReadString('\n'); err != nil || line != ": connected\n" {
+		t.Fatalf("expected connected comment, got: %q %v", line, err)
+	}
+
+	apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":"alice"}`)
+
+	var lines []string
+
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if lines[0] != "id: 1" || lines[1] != "event: call" || !strings.Contains(lines[2], `"operationId":"EventsController_trigger"`) {
+		t.Errorf("expected trigger call event, got: %q", lines)
+	}
+
+	// Closing the stream, as on shutdown, ends the response.
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := io.ReadAll(reader)
+		done <- err
+	}()
+
+	s.httpStream.Close()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected response to end, got: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("expected response to end after close, got: timeout")
+	}
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"mockserver/internal/catalog"
+	"mockserver/internal/stub"
+
+	"github.com/gorilla/mux"
+)
+
+// stubHandler responds to every non-internal request matching a registered
+// response stub, logging it under the matching API operation, and passes all
+// other requests to next.
+func (s *Server) stubHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if strings.HasPrefix(req.URL.Path, internalPathPrefix+"/") || s.stubs.Empty() {
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		body, err := io.ReadAll(req.Body)
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("request body error: %s", err), http.StatusBadRequest)
+
+			return
+		}
+
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		respond := s.stubs.Responder(req, body)
+
+		if respond == nil {
+			next.ServeHTTP(w, req)
+
+			return
+		}
+
+		// Log the call under its API operation, as the generated handler
+		// would have.
+		if operationId, ok := catalog.OperationID(req); ok {
+			respond = s.httpFileDir.HandlerFunc(operationId, respond)
+		}
+
+		respond(w, req)
+	})
+}
+
+// stubsHandler returns the registered response stubs of the namespace query
+// parameter, or all of them, in matching order.
+func (s *Server) stubsHandler(w http.ResponseWriter, req *http.Request) {
+	type stubsModel struct {
+		Stubs []*stub.Stub `json:"stubs"`
+	}
+
+	writeJSON(w, http.StatusOK, stubsModel{
+		Stubs: s.stubs.List(req.URL.Query().Get("namespace")),
+	})
+}
+
+// stubCreateHandler registers the response stub in the request body.
+func (s *Server) stubCreateHandler(w http.ResponseWriter, req *http.Request) {
+	var st stub.Stub
+
+	decoder := json.NewDecoder(req.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&st)
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("stub request body error: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	result, err := s.stubs.Add(st)
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid stub: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// stubsClearHandler removes the response stubs of the namespace query
+// parameter, or all of them along with their sequence counters.
+func (s *Server) stubsClearHandler(w http.ResponseWriter, req *http.Request) {
+	s.stubs.Clear(req.URL.Query().Get("namespace"))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// stubDeleteHandler removes a single response stub.
+func (s *Server) stubDeleteHandler(w http.ResponseWriter, req *http.Request) {
+	id := mux.Vars(req)["stubId"]
+
+	if !s.stubs.Remove(id) {
+		http.Error(w, fmt.Sprintf("stub %s not found", id), http.StatusNotFound)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
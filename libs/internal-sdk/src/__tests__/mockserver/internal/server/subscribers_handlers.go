@@ -0,0 +1,250 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/state"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Maximum number of subscribers of a bulk create.
+	maxBulkCreateSubscribers = 500
+
+	// Default number of subscribers per search page.
+	defaultSubscribersLimit = 10
+
+	// Maximum number of subscribers per search page.
+	maxSubscribersLimit = 100
+)
+
+// subscribersHandler searches the subscribers of the authenticated
+// environment, filtered by the email, name, phone and subscriberId query
+// parameters and paged via the after and before cursors.
+func (s *Server) subscribersHandler(w http.ResponseWriter, req *http.Request) {
+	environment, err := s.apiEnvironment(req)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	query := req.URL.Query()
+	options := state.SubscriberListOptions{
+		After:          query.Get("after"),
+		Before:         query.Get("before"),
+		Email:          query.Get("email"),
+		IncludeCursor:  query.Get("includeCursor") == "true",
+		Name:           query.Get("name"),
+		OrderBy:        query.Get("orderBy"),
+		OrderDirection: query.Get("orderDirection"),
+		Phone:          query.Get("phone"),
+		SubscriberID:   query.Get("subscriberId"),
+	}
+
+	switch options.OrderBy {
+	case "", "createdAt", "updatedAt":
+	default:
+		s.writeAPIError(w, req, newValidationError("orderBy", "orderBy must be one of the following values: createdAt, updatedAt"))
+
+		return
+	}
+
+	switch options.OrderDirection {
+	case "", "ASC", "DESC":
+	default:
+		s.writeAPIError(w, req, newValidationError("orderDirection", "orderDirection must be one of the following values: ASC, DESC"))
+
+		return
+	}
+
+	options.Limit, err = queryInt(req, "limit", defaultSubscribersLimit)
+
+	if err == nil && (options.Limit < 1 || options.Limit > maxSubscribersLimit) {
+		err = newValidationError("limit", fmt.Sprintf("limit must be between 1 and %d", maxSubscribersLimit))
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	subscribers, previous, next, err := state.ListSubscribers(environment, options)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, components.ListSubscribersResponseDto{
+		Data:     subscribers,
+		Next:     next,
+		Previous: previous,
+	})
+}
+
+// subscribersBulkCreateHandler creates or updates each subscriber of the
+// request body independently, reporting created, updated and failed
+// subscribers.
+func (s *Server) subscribersBulkCreateHandler(w http.ResponseWriter, req *http.Request) {
+	// Subscribers are decoded individually, so one invalid subscriber only
+	// fails itself.
+	var body struct {
+		Subscribers []json.RawMessage `json:"subscribers"`
+	}
+
+	err := decodeAPIRequest(req, &body)
+
+	switch {
+	case err != nil:
+	case len(body.Subscribers) == 0:
+		err = newValidationError("subscribers", "subscribers should not be empty")
+	case len(body.Subscribers) > maxBulkCreateSubscribers:
+		err = newValidationError("subscribers", fmt.Sprintf("subscribers must contain no more than %d elements", maxBulkCreateSubscribers))
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result components.BulkCreateSubscriberResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result = state.BulkUpsertSubscribers(environment, body.Subscribers, s.now())
+
+		return nil
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusCreated, result)
+}
+
+// subscriberHandler returns a subscriber of the authenticated environment.
+func (s *Server) subscriberHandler(w http.ResponseWriter, req *http.Request) {
+	environment, err := s.apiEnvironment(req)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	result, err := state.GetSubscriber(environment, mux.Vars(req)["subscriberId"])
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
+
+// subscriberOnlineFlagHandler sets whether a subscriber of the authenticated
+// environment is online.
+func (s *Server) subscriberOnlineFlagHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.UpdateSubscriberOnlineFlagRequestDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result *components.SubscriberResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result, err = state.UpdateSubscriberOnlineFlag(environment, mux.Vars(req)["subscriberId"], body.IsOnline, s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
+
+// subscriberChannelHandler replaces the channel credentials of a subscriber of
+// the authenticated environment for a chat or push provider.
+func (s *Server) subscriberChannelHandler(w http.ResponseWriter, req *http.Request) {
+	s.updateSubscriberChannel(w, req, false)
+}
+
+// subscriberChannelModifyHandler merges the channel credentials of a
+// subscriber of the authenticated environment for a chat or push provider,
+// appending device tokens.
+func (s *Server) subscriberChannelModifyHandler(w http.ResponseWriter, req *http.Request) {
+	s.updateSubscriberChannel(w, req, true)
+}
+
+// updateSubscriberChannel replaces or merges the channel credentials of the
+// request body and writes the updated subscriber.
+func (s *Server) updateSubscriberChannel(w http.ResponseWriter, req *http.Request, merge bool) {
+	var body components.UpdateSubscriberChannelRequestDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err == nil && body.ProviderID == "" {
+		err = newValidationError("providerId", "providerId should not be empty")
+	}
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result *components.SubscriberResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result, err = state.UpdateSubscriberChannel(environment, mux.Vars(req)["subscriberId"], body, merge, s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
+
+// subscriberCredentialsDeleteHandler removes the channel credentials of a
+// subscriber of the authenticated environment for a chat or push provider.
+func (s *Server) subscriberCredentialsDeleteHandler(w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+
+	err := s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		return state.DeleteSubscriberCredentials(environment, vars["subscriberId"], vars["providerId"], s.now())
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
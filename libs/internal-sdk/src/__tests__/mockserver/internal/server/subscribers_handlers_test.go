@@ -0,0 +1,122 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestSubscriberChannelHandlers(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPut, "/v1/subscribers/alice/credentials", "dev-key", `{"providerId":"fcm","credentials":{"deviceTokens":["a"]}}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPatch, "/v1/subscribers/alice/credentials", "dev-key", `{"providerId":"fcm","credentials":{"deviceTokens":["a","b"]}}`)
+
+	var result components.SubscriberResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if status != http.StatusOK || len(result.Channels) != 1 || len(result.Channels[0].Credentials.DeviceTokens) != 2 {
+		t.Errorf("expected two device tokens, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPut, "/v1/subscribers/unknown/credentials", "dev-key", `{"providerId":"fcm","credentials":{}}`)
+
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodDelete, "/v1/subscribers/alice/credentials/fcm", "dev-key", "")
+
+	if status != http.StatusNoContent {
+		t.Errorf("expected status 204, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodDelete, "/v1/subscribers/alice/credentials/fcm", "dev-key", "")
+
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got: %d %s", status, body)
+	}
+}
+
+func TestSubscriberOnlineFlagHandler(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	_, ts := newTestServer(t, WithClock(func() time.Time { return now }))
+
+	status, body := apiCall(t, ts, http.MethodPatch, "/v1/subscribers/alice/online-status", "dev-key", `{"isOnline":true}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	for _, path := range []string{"/v2/subscribers/alice", "/v2/subscribers?subscriberId=alice"} {
+		var result components.SubscriberResponseDto
+
+		_, body = apiCall(t, ts, http.MethodGet, path, "dev-key", "")
+
+		if path != "/v2/subscribers/alice" {
+			var list components.ListSubscribersResponseDto
+
+			decodeAPIResponse(t, body, &list)
+
+			if len(list.Data) != 1 {
+				t.Fatalf("%s: expected one subscriber, got: %s", path, body)
+			}
+
+			result = list.Data[0]
+		} else {
+			decodeAPIResponse(t, body, &result)
+		}
+
+		if result.IsOnline == nil || !*result.IsOnline || result.LastOnlineAt == nil || *result.LastOnlineAt != "2025-01-02T03:04:05.000Z" {
+			t.Errorf("%s: expected stored online flag, got: %s", path, body)
+		}
+	}
+
+	status, body = apiCall(t, ts, http.MethodGet, "/v2/subscribers/unknown", "dev-key", "")
+
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got: %d %s", status, body)
+	}
+}
+
+func TestSubscribersBulkCreateHandler(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v1/subscribers/bulk", "dev-key", `{"subscribers":[{"subscriberId":"alice"},{"subscriberId":"bob"},{"email":"bob@example.com"}]}`)
+
+	var result components.BulkCreateSubscriberResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if status != http.StatusCreated || len(result.Updated) != 1 || len(result.Created) != 1 || len(result.Failed) != 1 {
+		t.Errorf("expected updated, created and failed subscribers, got: %d %s", status, body)
+	}
+
+	status, _ = apiCall(t, ts, http.MethodGet, "/v2/subscribers/bob", "dev-key", "")
+
+	if status != http.StatusOK {
+		t.Errorf("expected bob to be stored, got: %d", status)
+	}
+
+	subscribers := make([]string, maxBulkCreateSubscribers+1)
+
+	for i := range subscribers {
+		subscribers[i] = fmt.Sprintf(`{"subscriberId":"subscriber-%d"}`, i)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v1/subscribers/bulk", "dev-key", `{"subscribers":[`+strings.Join(subscribers, ",")+`]}`)
+
+	if status != http.StatusUnprocessableEntity || !strings.Contains(string(body), "subscribers must contain no more than 500 elements") {
+		t.Errorf("expected 422 validation error, got: %d %s", status, body)
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/state"
+	"mockserver/internal/webhook"
+)
+
+// webhookSendHandler posts the webhook event of the request body, which needs
+// a type and may have data, waiting for its delivery.
+func (s *Server) webhookSendHandler(w http.ResponseWriter, req *http.Request) {
+	if s.webhooks == nil {
+		http.Error(w, "webhook error: no webhook URL configured", http.StatusConflict)
+
+		return
+	}
+
+	var event webhook.Event
+
+	err := json.NewDecoder(req.Body).Decode(&event)
+
+	if err == nil && event.Type == "" {
+		err = fmt.Errorf("missing type")
+	}
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("webhook request body error: %s", err), http.StatusBadRequest)
+
+		return
+	}
+
+	if event.ID == "" {
+		event.ID = state.NewID()
+	}
+
+	if event.Timestamp == "" {
+		event.Timestamp = state.FormatTime(s.now())
+	}
+
+	err = s.webhooks.Send(req.Context(), event)
+
+	if err != nil {
+		http.Error(w, fmt.Sprintf("webhook error: %s", err), http.StatusBadGateway)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dispatchNotificationEvents posts a message.sent or message.failed event per
+// job of the notifications, followed by a workflow.completed event per
+// notification, if a webhook URL is configured.
+func (s *Server) dispatchNotificationEvents(notifications []components.ActivityNotificationResponseDto) {
+	if s.webhooks == nil {
+		return
+	}
+
+	var events []webhook.Event
+
+	timestamp := state.FormatTime(s.now())
+
+	for _, notification := range notifications {
+		run := map[string]any{
+			"transactionId": notification.TransactionID,
+		}
+
+		if notification.Subscriber != nil {
+			run["subscriberId"] = notification.Subscriber.SubscriberID
+		}
+
+		if notification.Template != nil && len(notification.Template.Triggers) > 0 {
+			run["workflowId"] = notification.Template.Triggers[0].Identifier
+		}
+
+		status := "completed"
+
+		for _, job := range notification.Jobs {
+			eventType := "message.sent"
+			data := map[string]any{
+				"_id":        job.ID,
+				"channel":    job.Type,
+				"providerId": job.ProviderID,
+				"status":     job.Status,
+				"stepId":     job.Step.ID,
+			}
+
+			if len(job.ExecutionDetails) > 0 {
+				data["detail"] = job.ExecutionDetails[len(job.ExecutionDetails)-1].Detail
+			}
+
+			if job.Status != "completed" {
+				eventType = "message.failed"
+				status = "failed"
+			}
+
+			for key, value := range run {
+				data[key] = value
+			}
+
+			events = append(events, webhook.Event{ID: state.NewID(), Type: eventType, Timestamp: timestamp, Data: data})
+		}
+
+		run["status"] = status
+
+		events = append(events, webhook.Event{ID: state.NewID(), Type: "workflow.completed", Timestamp: timestamp, Data: run})
+	}
+
+	s.webhooks.Dispatch(events...)
+}
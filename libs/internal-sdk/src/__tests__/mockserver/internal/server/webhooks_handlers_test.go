@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"mockserver/internal/webhook"
+)
+
+// newWebhookReceiver returns a server recording the webhook events posted to
+// it with a valid signature of the secret.
+func newWebhookReceiver(t *testing.T, secret string) (*httptest.Server, func() []webhook.Event) {
+	t.Helper()
+
+	var mutex sync.Mutex
+	var events []webhook.Event
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		signature := req.Header.Get(webhook.SignatureHeader)
+		timestamp, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+		unix, _ := strconv.ParseInt(timestamp, 10, 64)
+
+		if signature != webhook.Sign(secret, unix, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		var event webhook.Event
+
+		_ = json.Unmarshal(body, &event)
+
+		mutex.Lock()
+		events = append(events, event)
+		mutex.Unlock()
+	}))
+
+	t.Cleanup(ts.Close)
+
+	return ts, func() []webhook.Event {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return append([]webhook.Event(nil), events...)
+	}
+}
+
+func TestTriggerWebhookEvents(t *testing.T) {
+	receiver, events := newWebhookReceiver(t, "secret")
+	s, ts := newTestServer(t, WithWebhookURL(receiver.URL), WithWebhookSecret("secret"))
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v1/events/trigger", "dev-key", `{"name":"welcome","to":["alice","bob"]}`)
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	s.webhooks.Wait()
+
+	var types []string
+
+	for _, event := range events() {
+		types = append(types, event.Type+":"+event.Data["subscriberId"].(string))
+	}
+
+	expected := "message.sent:alice,message.sent:alice,workflow.completed:alice,message.sent:bob,message.failed:bob,workflow.completed:bob"
+
+	if strings.Join(types, ",") != expected {
+		t.Errorf("expected events %s, got: %v", expected, types)
+	}
+}
+
+func TestWebhookSendHandler(t *testing.T) {
+	receiver, events := newWebhookReceiver(t, "secret")
+	_, ts := newTestServer(t, WithWebhookURL(receiver.URL), WithWebhookSecret("secret"))
+
+	res, err := http.Post(ts.URL+"/_mockserver/webhooks", "application/json", strings.NewReader(`{"type":"message.seen","data":{"_id":"message-1"}}`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusNoContent || len(events()) != 1 || events()[0].Type != "message.seen" || events()[0].ID == "" {
+		t.Errorf("expected delivered message.seen event, got: %d %+v", res.StatusCode, events())
+	}
+
+	_, ts = newTestServer(t)
+
+	res, err = http.Post(ts.URL+"/_mockserver/webhooks", "application/json", strings.NewReader(`{"type":"message.seen"}`))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusConflict {
+		t.Errorf("expected status 409 without webhook URL, got: %d", res.StatusCode)
+	}
+}
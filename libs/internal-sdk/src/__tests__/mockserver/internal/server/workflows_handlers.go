@@ -0,0 +1,148 @@
+package server
+
+import (
+	"net/http"
+
+	"mockserver/internal/bridge"
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/state"
+
+	"github.com/gorilla/mux"
+)
+
+// workflowSyncHandler promotes a workflow of the authenticated environment
+// into the target environment of the request body. If the authenticated
+// environment has a bridge application, its workflows are discovered and
+// imported before the sync, and discovery failures fail the sync.
+func (s *Server) workflowSyncHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.SyncWorkflowDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	if body.TargetEnvironmentID == "" {
+		s.writeAPIError(w, req, newValidationError("targetEnvironmentId", "targetEnvironmentId should not be empty"))
+
+		return
+	}
+
+	environment, err := s.apiEnvironment(req)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var discovered *bridge.DiscoverResponse
+
+	// Discover outside of the state lock, as the bridge application may be
+	// slow or call back into the mock server.
+	if environment.Bridge != nil && environment.Bridge.URL != nil && *environment.Bridge.URL != "" {
+		discovered, err = bridge.Discover(req.Context(), http.DefaultClient, *environment.Bridge.URL)
+
+		if err != nil {
+			s.writeAPIError(w, req, newAPIError(http.StatusBadGateway, "Bridge discovery failed: %s", err))
+
+			return
+		}
+	}
+
+	var result *components.WorkflowResponseDto
+
+	err = s.state.Update(func(environments []state.EnvironmentState) error {
+		source, err := apiEnvironmentIndex(req, environments)
+
+		if err != nil {
+			return err
+		}
+
+		if discovered != nil {
+			err = state.ImportBridgeWorkflows(&environments[source], discovered.Workflows, s.now())
+
+			if err != nil {
+				return err
+			}
+		}
+
+		target := state.EnvironmentIndex(environments, body.TargetEnvironmentID)
+
+		if target < 0 {
+			return newAPIError(http.StatusNotFound, "Environment %s not found", body.TargetEnvironmentID)
+		}
+
+		if target == source {
+			return newAPIError(http.StatusBadRequest, "Cannot sync workflow to the same environment")
+		}
+
+		result, err = state.SyncWorkflow(&environments[source], &environments[target], mux.Vars(req)["workflowId"], s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
+
+// workflowDuplicateHandler deep-copies a workflow of the authenticated
+// environment under a new name.
+func (s *Server) workflowDuplicateHandler(w http.ResponseWriter, req *http.Request) {
+	var body components.DuplicateWorkflowDto
+
+	err := decodeAPIRequest(req, &body)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	var result *components.WorkflowResponseDto
+
+	err = s.updateAPIEnvironment(req, func(environment *state.EnvironmentState) error {
+		result, err = state.DuplicateWorkflow(environment, mux.Vars(req)["workflowId"], body, s.now())
+
+		return err
+	})
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusCreated, result)
+}
+
+// workflowStepDataHandler returns a step of a workflow of the authenticated
+// environment with the data and UI schemas of its controls and the variables
+// available to it.
+func (s *Server) workflowStepDataHandler(w http.ResponseWriter, req *http.Request) {
+	environment, err := s.apiEnvironment(req)
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	result, err := state.StepData(environment, mux.Vars(req)["workflowId"], mux.Vars(req)["stepId"])
+
+	if err != nil {
+		s.writeAPIError(w, req, err)
+
+		return
+	}
+
+	writeAPIResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,203 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestWorkflowSyncHandler(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPut, "/v2/workflows/welcome/sync", "dev-key", `{"targetEnvironmentId":"env-prod"}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	var created components.WorkflowResponseDto
+
+	decodeAPIResponse(t, body, &created)
+
+	if created.WorkflowID != "welcome" || created.ID == "wf-dev-welcome" {
+		t.Errorf("expected new workflow welcome, got: %s %s", created.WorkflowID, created.ID)
+	}
+
+	// Syncing again updates the workflow in place.
+	status, body = apiCall(t, ts, http.MethodPut, "/v2/workflows/wf-dev-welcome/sync", "dev-key", `{"targetEnvironmentId":"env-prod"}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	var updated components.WorkflowResponseDto
+
+	decodeAPIResponse(t, body, &updated)
+
+	if updated.ID != created.ID {
+		t.Errorf("expected database identifier %s to be kept, got: %s", created.ID, updated.ID)
+	}
+
+	prod := s.state.Environments()[1]
+
+	if len(prod.Workflows) != 1 || len(prod.Layouts) != 1 {
+		t.Fatalf("expected 1 workflow and 1 layout in target, got: %d, %d", len(prod.Workflows), len(prod.Layouts))
+	}
+
+	for _, test := range []struct {
+		status int
+		body   string
+		path   string
+	}{
+		{status: http.StatusNotFound, path: "/v2/workflows/unknown/sync", body: `{"targetEnvironmentId":"env-prod"}`},
+		{status: http.StatusNotFound, path: "/v2/workflows/welcome/sync", body: `{"targetEnvironmentId":"env-unknown"}`},
+		{status: http.StatusBadRequest, path: "/v2/workflows/welcome/sync", body: `{"targetEnvironmentId":"env-dev"}`},
+		{status: http.StatusUnprocessableEntity, path: "/v2/workflows/welcome/sync", body: `{}`},
+	} {
+		status, body := apiCall(t, ts, http.MethodPut, test.path, "dev-key", test.body)
+
+		if status != test.status {
+			t.Errorf("%s %s: expected status %d, got: %d %s", test.path, test.body, test.status, status, body)
+		}
+	}
+}
+
+func TestWorkflowSyncHandlerBridge(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	var discoverQuery string
+
+	bridgeServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		discoverQuery = req.URL.RawQuery
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"workflows":[{"workflowId":"code-first","steps":[{"stepId":"inbox","type":"in_app","controls":{"schema":{"type":"object"}}}]}]}`))
+	}))
+	t.Cleanup(bridgeServer.Close)
+
+	status, body := apiCall(t, ts, http.MethodPut, "/v1/environments/env-dev", "dev-key", `{"bridge":{"url":"`+bridgeServer.URL+`"}}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPut, "/v2/workflows/code-first/sync", "dev-key", `{"targetEnvironmentId":"env-prod"}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	if discoverQuery != "action=discover" {
+		t.Errorf("expected discover action, got: %s", discoverQuery)
+	}
+
+	var result components.WorkflowResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if result.WorkflowID != "code-first" || result.Origin != components.ResourceOriginEnumExternal || len(result.Steps) != 1 {
+		t.Errorf("expected discovered workflow, got: %+v", result)
+	}
+
+	if dev := s.state.Environments()[0]; dev.WorkflowIndex("code-first") < 0 {
+		t.Error("expected discovered workflow to be imported into the source environment")
+	}
+
+	bridgeServer.Close()
+
+	status, body = apiCall(t, ts, http.MethodPut, "/v2/workflows/code-first/sync", "dev-key", `{"targetEnvironmentId":"env-prod"}`)
+
+	if status != http.StatusBadGateway {
+		t.Errorf("expected status 502 for unreachable bridge, got: %d %s", status, body)
+	}
+}
+
+func TestEnvironmentUpdateHandler(t *testing.T) {
+	s, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPut, "/v1/environments/env-prod", "dev-key", `{"name":"Staging","bridge":{"url":"http://localhost:4000/api/novu"}}`)
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	var result components.EnvironmentResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if result.Name != "Staging" {
+		t.Errorf("expected name Staging, got: %s", result.Name)
+	}
+
+	prod := s.state.Environments()[1]
+
+	if prod.Bridge == nil || prod.Bridge.URL == nil || *prod.Bridge.URL != "http://localhost:4000/api/novu" {
+		t.Errorf("expected bridge URL to be stored, got: %+v", prod.Bridge)
+	}
+
+	for _, test := range []struct {
+		status int
+		body   string
+		path   string
+	}{
+		{status: http.StatusNotFound, path: "/v1/environments/env-unknown", body: `{}`},
+		{status: http.StatusConflict, path: "/v1/environments/env-prod", body: `{"identifier":"` + s.state.Environments()[0].Environment.Identifier + `"}`},
+	} {
+		status, body := apiCall(t, ts, http.MethodPut, test.path, "dev-key", test.body)
+
+		if status != test.status {
+			t.Errorf("%s %s: expected status %d, got: %d %s", test.path, test.body, test.status, status, body)
+		}
+	}
+}
+
+func TestWorkflowDuplicateHandler(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodPost, "/v2/workflows/welcome/duplicate", "dev-key", `{"name":"Welcome Tenant A"}`)
+
+	if status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d %s", status, body)
+	}
+
+	var result components.WorkflowResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if result.WorkflowID != "welcome-tenant-a" || len(result.Steps) != 2 {
+		t.Errorf("unexpected duplicate: %s", body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodPost, "/v2/workflows/welcome/duplicate", "dev-key", `{"name":"Welcome Tenant A"}`)
+
+	if status != http.StatusConflict || !strings.Contains(string(body), `"statusCode":409`) {
+		t.Errorf("expected 409 ErrorDto, got: %d %s", status, body)
+	}
+}
+
+func TestWorkflowStepDataHandler(t *testing.T) {
+	_, ts := newTestServer(t)
+
+	status, body := apiCall(t, ts, http.MethodGet, "/v2/workflows/welcome/steps/email", "dev-key", "")
+
+	if status != http.StatusOK {
+		t.Fatalf("expected status 200, got: %d %s", status, body)
+	}
+
+	var result components.StepResponseDto
+
+	decodeAPIResponse(t, body, &result)
+
+	if result.Type != components.StepTypeEnumEmail || result.Controls.UISchema == nil || len(result.Controls.DataSchema) == 0 {
+		t.Errorf("expected email step with schemas, got: %s", body)
+	}
+
+	status, body = apiCall(t, ts, http.MethodGet, "/v2/workflows/welcome/steps/unknown", "dev-key", "")
+
+	if status != http.StatusNotFound {
+		t.Errorf("expected status 404, got: %d %s", status, body)
+	}
+}
@@ -0,0 +1,124 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"mockserver/internal/bridge"
+	"mockserver/internal/sdk/models/components"
+)
+
+// ImportBridgeWorkflows stores the workflows discovered from the bridge
+// application of the environment, with an external origin. Workflows are
+// matched on their workflow identifier: an existing workflow is replaced,
+// keeping its database identifier and the database identifiers of steps with
+// the same step identifier, otherwise it is created.
+//
+// Step controls are stored as the data schema of the step without control
+// values, since a bridge application renders its steps itself.
+func ImportBridgeWorkflows(environment *EnvironmentState, workflows []bridge.Workflow, now time.Time) error {
+	for _, discovered := range workflows {
+		if discovered.WorkflowID == "" {
+			return fmt.Errorf("discovered workflow without workflowId: %w", ErrInvalid)
+		}
+
+		var existing *components.WorkflowResponseDto
+
+		if index := environment.WorkflowIndex(discovered.WorkflowID); index >= 0 {
+			existing = &environment.Workflows[index]
+		}
+
+		workflow, err := bridgeWorkflow(discovered, existing, now)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			*existing = *workflow
+		} else {
+			environment.Workflows = append(environment.Workflows, *workflow)
+		}
+	}
+
+	return nil
+}
+
+// bridgeWorkflow returns the stored form of the discovered workflow, keeping
+// the database identifiers of the existing workflow if there is one.
+func bridgeWorkflow(discovered bridge.Workflow, existing *components.WorkflowResponseDto, now time.Time) (*components.WorkflowResponseDto, error) {
+	id := NewID()
+	createdAt := FormatTime(now)
+
+	if existing != nil {
+		id = existing.ID
+		createdAt = existing.CreatedAt
+	}
+
+	name := discovered.Name
+
+	if name == "" {
+		name = discovered.WorkflowID
+	}
+
+	tags := discovered.Tags
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	steps := make([]any, 0, len(discovered.Steps))
+
+	for _, step := range discovered.Steps {
+		switch components.StepTypeEnum(step.Type) {
+		case components.StepTypeEnumInApp, components.StepTypeEnumEmail, components.StepTypeEnumSms, components.StepTypeEnumChat, components.StepTypeEnumPush,
+			components.StepTypeEnumDigest, components.StepTypeEnumDelay, components.StepTypeEnumCustom:
+		default:
+			return nil, fmt.Errorf("discovered workflow %s step %s has unsupported type %q: %w", discovered.WorkflowID, step.StepID, step.Type, ErrInvalid)
+		}
+
+		stepID := syncedStepID(existing, step.StepID)
+
+		steps = append(steps, map[string]any{
+			"_id":                stepID,
+			"stepId":             step.StepID,
+			"name":               step.StepID,
+			"slug":               Slugify(step.StepID) + "_st_" + stepID,
+			"type":               step.Type,
+			"origin":             components.ResourceOriginEnumExternal,
+			"workflowId":         discovered.WorkflowID,
+			"workflowDatabaseId": id,
+			"controls": map[string]any{
+				"dataSchema": step.Controls.Schema,
+				"values":     map[string]any{},
+			},
+			"variables": map[string]any{},
+		})
+	}
+
+	result, err := convert[components.WorkflowResponseDto](map[string]any{
+		"_id":           id,
+		"workflowId":    discovered.WorkflowID,
+		"name":          name,
+		"description":   discovered.Description,
+		"tags":          tags,
+		"active":        true,
+		"slug":          discovered.WorkflowID + "_wf_" + id,
+		"createdAt":     createdAt,
+		"updatedAt":     FormatTime(now),
+		"steps":         steps,
+		"origin":        components.ResourceOriginEnumExternal,
+		"status":        components.WorkflowStatusEnumActive,
+		"payloadSchema": discovered.Payload.Schema,
+		"preferences": map[string]any{
+			"default": map[string]any{
+				"all":      map[string]any{"enabled": true, "readOnly": false},
+				"channels": map[string]any{},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discovered workflow %s: %w", discovered.WorkflowID, err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,74 @@
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mockserver/internal/bridge"
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestImportBridgeWorkflows(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	existing := dev.Workflows[0]
+	existingStepID := stepString(&existing.Steps[1], "ID")
+
+	workflows := []bridge.Workflow{
+		{
+			WorkflowID: existing.WorkflowID,
+			Payload:    bridge.Schema{Schema: map[string]any{"type": "object"}},
+			Steps: []bridge.Step{
+				{StepID: stepString(&existing.Steps[1], "StepID"), Type: "email", Controls: bridge.Schema{Schema: map[string]any{"type": "object"}}},
+				{StepID: "wait", Type: "delay"},
+			},
+		},
+		{
+			WorkflowID: "code-first",
+			Name:       "Code First",
+			Steps:      []bridge.Step{{StepID: "inbox", Type: "in_app"}},
+		},
+	}
+
+	err := ImportBridgeWorkflows(dev, workflows, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	updated := dev.Workflows[dev.WorkflowIndex(existing.WorkflowID)]
+
+	if updated.ID != existing.ID || updated.CreatedAt != existing.CreatedAt || updated.Origin != components.ResourceOriginEnumExternal {
+		t.Errorf("expected existing workflow to be replaced in place with external origin, got: %+v", updated)
+	}
+
+	if len(updated.Steps) != 2 || stepString(&updated.Steps[0], "ID") != existingStepID || updated.Steps[1].Type != components.WorkflowResponseDtoStepTypeDelay {
+		t.Errorf("expected discovered steps keeping matching step identifiers, got: %+v", updated.Steps)
+	}
+
+	if updated.Steps[0].EmailStepResponseDto.Controls.DataSchema["type"] != "object" || updated.PayloadSchema["type"] != "object" {
+		t.Errorf("expected control and payload schemas, got: %+v, %+v", updated.Steps[0].EmailStepResponseDto.Controls, updated.PayloadSchema)
+	}
+
+	created := dev.Workflows[dev.WorkflowIndex("code-first")]
+
+	if created.Name != "Code First" || created.Status != components.WorkflowStatusEnumActive || stepString(&created.Steps[0], "WorkflowDatabaseID") != created.ID {
+		t.Errorf("expected created workflow, got: %+v", created)
+	}
+}
+
+func TestImportBridgeWorkflowsInvalid(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	count := len(dev.Workflows)
+
+	err := ImportBridgeWorkflows(dev, []bridge.Workflow{{WorkflowID: "http", Steps: []bridge.Step{{StepID: "call", Type: "http_request"}}}}, time.Now())
+
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got: %v", err)
+	}
+
+	if len(dev.Workflows) != count {
+		t.Errorf("expected no workflow to be created, got: %d", len(dev.Workflows))
+	}
+}
@@ -0,0 +1,31 @@
+package state
+
+import (
+	"fmt"
+
+	"mockserver/internal/sdk/utils"
+)
+
+// clone returns a deep copy of v. The copy is made via a JSON round trip with
+// [utils.MarshalJSON], so additional properties of the API types are kept.
+func clone[T any](v T) (T, error) {
+	return convert[T](v)
+}
+
+// convert returns v decoded as a T, via a JSON round trip with
+// [utils.MarshalJSON]. Fields of v unknown to T are ignored.
+func convert[T any](v any) (T, error) {
+	var result T
+
+	data, err := utils.MarshalJSON(v, "", true)
+	if err != nil {
+		return result, fmt.Errorf("error converting %T: %w", v, err)
+	}
+
+	err = utils.UnmarshalJSON(data, &result, "", true, false)
+	if err != nil {
+		return result, fmt.Errorf("error converting %T to %T: %w", v, result, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,388 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"strings"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+const (
+	// Provider of in-app steps in environments without an in-app
+	// integration.
+	defaultInAppProviderID = "novu"
+
+	// Status of delivered jobs.
+	jobStatusCompleted = "completed"
+
+	// Status of jobs which could not be delivered.
+	jobStatusFailed = "failed"
+)
+
+// templateVariable matches {{ path }} placeholders of step content, such as
+// {{payload.name}} or {{subscriber.firstName}}.
+var templateVariable = regexp.MustCompile(`{{\s*([\w.]+)\s*}}`)
+
+// deliver renders each channel step of the workflow for the subscriber and
+// returns a job per step. The provider payload of a job is recorded as the
+// raw value of its execution details, with the trigger overrides merged in
+// the order channel, provider and step provider overrides.
+//
+// Each step is delivered through the primary or first active integration of
+// its channel, with the outcome configured for that integration. Once an
+// integration has failed permanently or exhausted its attempts, delivery
+// fails over to the next active integration of the channel. Steps without an
+// active integration for their channel, or subscribers without the address or
+// credentials the channel needs, result in a failed job. Preferences and step
+// conditions are not applied.
+func deliver(environment *EnvironmentState, workflow *components.WorkflowResponseDto, subscriber *components.SubscriberResponseDto, dto components.TriggerEventRequestDto, now time.Time) ([]components.ActivityNotificationJobResponseDto, error) {
+	subscriberVariables, err := convert[map[string]any](subscriber)
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides map[string]any
+
+	if dto.Overrides != nil {
+		overrides, err = convert[map[string]any](dto.Overrides)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	variables := map[string]any{
+		"payload":    dto.Payload,
+		"subscriber": subscriberVariables,
+	}
+
+	var result []components.ActivityNotificationJobResponseDto
+
+	for i := range workflow.Steps {
+		step := &workflow.Steps[i]
+		channel, ok := stepChannel(step)
+
+		if !ok {
+			continue
+		}
+
+		content, err := stepContent(environment, step, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		timestamp := FormatTime(now)
+		stepName := stepString(step, "Name")
+		job := components.ActivityNotificationJobResponseDto{
+			ID:   NewID(),
+			Type: components.ActivityNotificationJobResponseDtoType(step.Type),
+			Step: components.ActivityNotificationStepResponseDto{
+				ID:         stepString(step, "ID"),
+				Active:     true,
+				Filters:    []components.StepFilterDto{},
+				TemplateID: stepString(step, "ID"),
+				Name:       &stepName,
+			},
+			Overrides: overrides,
+			Status:    jobStatusFailed,
+			UpdatedAt: &timestamp,
+		}
+
+		integrations := environment.deliveryIntegrations(channel)
+
+		// In-app steps are delivered by the default provider without an
+		// integration.
+		if len(integrations) == 0 && channel == components.IntegrationResponseDtoChannelInApp {
+			integrations = []*components.IntegrationResponseDto{nil}
+		}
+
+		if len(integrations) == 0 {
+			job.ExecutionDetails = append(job.ExecutionDetails, executionDetail("", components.ExecutionDetailsStatusEnumFailed, fmt.Sprintf("Subscriber does not have an active %s integration", channel), nil, now))
+		}
+
+		for _, integration := range integrations {
+			job.ProviderID = defaultInAppProviderID
+
+			if integration != nil {
+				job.ProviderID = components.ProvidersIDEnum(integration.ProviderID)
+			}
+
+			payload, failure := providerPayload(channel, integration, subscriber, content)
+
+			if failure != "" {
+				job.ExecutionDetails = append(job.ExecutionDetails, executionDetail(string(job.ProviderID), components.ExecutionDetailsStatusEnumFailed, failure, nil, now))
+
+				continue
+			}
+
+			raw, err := providerRaw(payload, overrides, string(channel), string(job.ProviderID), stepString(step, "StepID"))
+			if err != nil {
+				return nil, err
+			}
+
+			details, sent := attemptDelivery(environment.integrationOutcome(integration), string(job.ProviderID), raw, now)
+			job.ExecutionDetails = append(job.ExecutionDetails, details...)
+			job.UpdatedAt = details[len(details)-1].CreatedAt
+
+			if sent {
+				job.Status = jobStatusCompleted
+
+				break
+			}
+		}
+
+		result = append(result, job)
+	}
+
+	return result, nil
+}
+
+// deliveryIntegrations returns the active integrations of the channel in
+// delivery order, with the primary integration first.
+func (e *EnvironmentState) deliveryIntegrations(channel components.IntegrationResponseDtoChannel) []*components.IntegrationResponseDto {
+	var result []*components.IntegrationResponseDto
+
+	for i := range e.Integrations {
+		integration := &e.Integrations[i]
+
+		if integration.Channel != channel || !integration.Active || integration.Deleted {
+			continue
+		}
+
+		if integration.Primary {
+			result = append([]*components.IntegrationResponseDto{integration}, result...)
+		} else {
+			result = append(result, integration)
+		}
+	}
+
+	return result
+}
+
+// stepContent returns the control values of the step rendered with the
+// variables. The body of email steps is wrapped in the content of their
+// layout, or the default layout without a layout identifier.
+func stepContent(environment *EnvironmentState, step *components.WorkflowResponseDtoStep, variables map[string]any) (map[string]any, error) {
+	result := map[string]any{}
+	variant := stepVariant(step)
+
+	if variant.IsValid() {
+		if controlValues := variant.FieldByName("ControlValues"); !controlValues.IsNil() {
+			values, err := convert[map[string]any](controlValues.Interface())
+			if err != nil {
+				return nil, fmt.Errorf("error decoding control values of step %s: %w", stepString(step, "StepID"), err)
+			}
+
+			result = values
+		}
+	}
+
+	if step.Type == components.WorkflowResponseDtoStepTypeEmail {
+		index := environment.DefaultLayoutIndex()
+
+		if layoutID := stepLayoutID(step); layoutID != "" {
+			index = environment.LayoutIndex(layoutID)
+		}
+
+		if index >= 0 {
+			values := environment.Layouts[index].Controls.Values
+
+			if values.Email != nil && values.Email.Content != "" {
+				body, _ := result["body"].(string)
+				result["body"] = strings.ReplaceAll(values.Email.Content, "{{content}}", body)
+			}
+		}
+	}
+
+	return render(result, variables).(map[string]any), nil
+}
+
+// providerPayload returns the payload the integration of the channel would
+// receive for the rendered content, or the failure detail if the subscriber
+// lacks the address or credentials the channel needs.
+func providerPayload(channel components.IntegrationResponseDtoChannel, integration *components.IntegrationResponseDto, subscriber *components.SubscriberResponseDto, content map[string]any) (map[string]any, string) {
+	result := map[string]any{}
+
+	switch channel {
+	case components.IntegrationResponseDtoChannelEmail:
+		if subscriber.Email == nil || *subscriber.Email == "" {
+			return nil, "Subscriber does not have an email address"
+		}
+
+		result["to"] = []any{*subscriber.Email}
+		result["subject"] = content["subject"]
+		result["html"] = content["body"]
+
+		if integration.Credentials.From != nil {
+			result["from"] = *integration.Credentials.From
+		}
+
+		if integration.Credentials.SenderName != nil {
+			result["senderName"] = *integration.Credentials.SenderName
+		}
+	case components.IntegrationResponseDtoChannelSms:
+		if subscriber.Phone == nil || *subscriber.Phone == "" {
+			return nil, "Subscriber does not have a phone number"
+		}
+
+		result["to"] = *subscriber.Phone
+		result["content"] = content["body"]
+
+		if integration.Credentials.From != nil {
+			result["from"] = *integration.Credentials.From
+		}
+	case components.IntegrationResponseDtoChannelPush:
+		credentials := subscriberCredentials(subscriber, integration)
+
+		if credentials == nil || len(credentials.DeviceTokens) == 0 {
+			return nil, fmt.Sprintf("Subscriber does not have %s device tokens", integration.ProviderID)
+		}
+
+		target := make([]any, 0, len(credentials.DeviceTokens))
+
+		for _, token := range credentials.DeviceTokens {
+			target = append(target, token)
+		}
+
+		result["target"] = target
+		result["title"] = content["subject"]
+		result["content"] = content["body"]
+		result["payload"] = map[string]any{}
+	case components.IntegrationResponseDtoChannelChat:
+		credentials := subscriberCredentials(subscriber, integration)
+
+		if credentials == nil || credentials.WebhookURL == nil {
+			return nil, fmt.Sprintf("Subscriber does not have a %s webhook URL", integration.ProviderID)
+		}
+
+		result["webhookUrl"] = *credentials.WebhookURL
+		result["content"] = content["body"]
+
+		if credentials.Channel != nil {
+			result["channel"] = *credentials.Channel
+		}
+	default:
+		result["subject"] = content["subject"]
+		result["body"] = content["body"]
+	}
+
+	return result, ""
+}
+
+// subscriberCredentials returns the credentials the subscriber stored for the
+// integration, or nil if there are none.
+func subscriberCredentials(subscriber *components.SubscriberResponseDto, integration *components.IntegrationResponseDto) *components.ChannelCredentials {
+	for i, channel := range subscriber.Channels {
+		if integration.ID != nil && channel.IntegrationID == *integration.ID {
+			return &subscriber.Channels[i].Credentials
+		}
+	}
+
+	for i, channel := range subscriber.Channels {
+		if string(channel.ProviderID) == integration.ProviderID {
+			return &subscriber.Channels[i].Credentials
+		}
+	}
+
+	return nil
+}
+
+// providerRaw returns the provider payload as JSON, with the channel,
+// provider and step provider overrides merged in that order.
+func providerRaw(payload map[string]any, overrides map[string]any, channel string, providerID string, stepID string) (string, error) {
+	providers, _ := overrides["providers"].(map[string]any)
+	steps, _ := overrides["steps"].(map[string]any)
+	step, _ := steps[stepID].(map[string]any)
+	stepProviders, _ := step["providers"].(map[string]any)
+
+	for _, override := range []any{overrides[channel], providers[providerID], stepProviders[providerID]} {
+		if override, ok := override.(map[string]any); ok {
+			payload = mergeMaps(payload, override)
+		}
+	}
+
+	result, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("error encoding %s provider payload: %w", providerID, err)
+	}
+
+	return string(result), nil
+}
+
+// mergeMaps returns a copy of target with the values of source deep-merged
+// into it. Nested objects are merged, while all other values are replaced.
+func mergeMaps(target map[string]any, source map[string]any) map[string]any {
+	result := maps.Clone(target)
+
+	for key, value := range source {
+		sourceMap, sourceOK := value.(map[string]any)
+		targetMap, targetOK := result[key].(map[string]any)
+
+		if sourceOK && targetOK {
+			result[key] = mergeMaps(targetMap, sourceMap)
+
+			continue
+		}
+
+		result[key] = value
+	}
+
+	return result
+}
+
+// render replaces the {{ path }} placeholders of all strings within value by
+// the variables at their path. Placeholders of unknown variables are replaced
+// by an empty string.
+func render(value any, variables map[string]any) any {
+	switch v := value.(type) {
+	case string:
+		return templateVariable.ReplaceAllStringFunc(v, func(match string) string {
+			variable := lookup(variables, templateVariable.FindStringSubmatch(match)[1])
+
+			if variable == nil {
+				return ""
+			}
+
+			return fmt.Sprint(variable)
+		})
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		for key, item := range v {
+			result[key] = render(item, variables)
+		}
+
+		return result
+	case []any:
+		result := make([]any, len(v))
+
+		for i, item := range v {
+			result[i] = render(item, variables)
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// lookup returns the variable at the dot-separated path, or nil if there is
+// none.
+func lookup(variables map[string]any, path string) any {
+	var result any = variables
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := result.(map[string]any)
+
+		if !ok {
+			return nil
+		}
+
+		result = object[key]
+	}
+
+	return result
+}
@@ -0,0 +1,155 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// deliveredPayload returns the decoded provider payload of the job of the
+// step type in the last notification.
+func deliveredPayload(t *testing.T, environment *EnvironmentState, jobType components.ActivityNotificationJobResponseDtoType) (components.ActivityNotificationJobResponseDto, map[string]any) {
+	t.Helper()
+
+	notification := environment.Notifications[len(environment.Notifications)-1]
+
+	for _, job := range notification.Jobs {
+		if job.Type != jobType {
+			continue
+		}
+
+		if len(job.ExecutionDetails) != 1 || job.ExecutionDetails[0].Raw == nil {
+			return job, nil
+		}
+
+		var result map[string]any
+
+		err := json.Unmarshal([]byte(*job.ExecutionDetails[0].Raw), &result)
+		if err != nil {
+			t.Fatalf("unexpected error decoding provider payload: %s", err)
+		}
+
+		return job, result
+	}
+
+	t.Fatalf("expected %s job, got: %+v", jobType, notification.Jobs)
+
+	return components.ActivityNotificationJobResponseDto{}, nil
+}
+
+func TestTriggerEventDelivery(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	firstName := "Alice"
+
+	dev.Subscribers[0].FirstName = &firstName
+	dev.Layouts[0].Controls.Values.Email = &components.EmailControlsDto{Content: "<div>{{content}}</div>"}
+
+	_, err := TriggerEvent(dev, components.TriggerEventRequestDto{
+		WorkflowID: "welcome",
+		To:         components.CreateToUnion2Str("alice"),
+		Payload:    map[string]any{"name": "Ada"},
+		Overrides: &components.TriggerEventRequestDtoOverrides{
+			Email:     map[string]any{"from": "channel@example.com", "subject": "Channel"},
+			Providers: map[string]map[string]any{"sendgrid": {"subject": "Provider", "customData": map[string]any{"a": 1.0}}},
+			Steps: map[string]components.StepsOverrides{
+				"email": {Providers: map[string]map[string]any{"sendgrid": {"subject": "Step", "customData": map[string]any{"b": 2.0}}}},
+			},
+		},
+	}, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	job, payload := deliveredPayload(t, dev, components.ActivityNotificationJobResponseDtoTypeEmail)
+
+	if job.Status != "completed" || job.ProviderID != "sendgrid" || job.ExecutionDetails[0].Status != components.ExecutionDetailsStatusEnumSuccess {
+		t.Errorf("expected completed sendgrid job, got: %+v", job)
+	}
+
+	for key, expected := range map[string]string{
+		"from":    "channel@example.com",
+		"subject": "Step",
+		"html":    "<div>Hello Ada</div>",
+	} {
+		if payload[key] != expected {
+			t.Errorf("expected email %s %q, got: %v", key, expected, payload[key])
+		}
+	}
+
+	if customData, _ := payload["customData"].(map[string]any); customData["a"] != 1.0 || customData["b"] != 2.0 {
+		t.Errorf("expected deep-merged custom data, got: %v", payload["customData"])
+	}
+
+	_, payload = deliveredPayload(t, dev, components.ActivityNotificationJobResponseDtoTypeInApp)
+
+	if payload["body"] != "Welcome Alice" {
+		t.Errorf("expected rendered in-app body, got: %v", payload)
+	}
+}
+
+func TestTriggerEventDeliveryFailures(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	title := "Hi {{payload.name}}"
+
+	dev.Workflows[0].Steps = append(dev.Workflows[0].Steps, components.CreateWorkflowResponseDtoStepPush(components.PushStepResponseDto{
+		ID:            "step-dev-push",
+		StepID:        "push",
+		Name:          "Push",
+		ControlValues: &components.PushStepResponseDtoControlValues{Subject: &title},
+	}))
+
+	trigger := func(to string) {
+		t.Helper()
+
+		_, err := TriggerEvent(dev, components.TriggerEventRequestDto{
+			WorkflowID: "welcome",
+			To:         components.CreateToUnion2Str(to),
+			Payload:    map[string]any{"name": "Ada"},
+			Overrides:  &components.TriggerEventRequestDtoOverrides{Push: map[string]any{"payload": map[string]any{"deepLink": "/welcome"}}},
+		}, now)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	trigger("bob")
+
+	job, payload := deliveredPayload(t, dev, components.ActivityNotificationJobResponseDtoTypeEmail)
+
+	if job.Status != "failed" || payload != nil || job.ExecutionDetails[0].Detail != "Subscriber does not have an email address" {
+		t.Errorf("expected failed email job, got: %+v", job)
+	}
+
+	job, _ = deliveredPayload(t, dev, components.ActivityNotificationJobResponseDtoTypePush)
+
+	if job.Status != "failed" || job.ExecutionDetails[0].Detail != "Subscriber does not have fcm device tokens" {
+		t.Errorf("expected failed push job, got: %+v", job)
+	}
+
+	_, err := UpdateSubscriberChannel(dev, "bob", components.UpdateSubscriberChannelRequestDto{
+		ProviderID:  components.ChatOrPushProviderEnumFcm,
+		Credentials: components.ChannelCredentials{DeviceTokens: []string{"token"}},
+	}, false, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	trigger("bob")
+
+	job, payload = deliveredPayload(t, dev, components.ActivityNotificationJobResponseDtoTypePush)
+
+	if job.Status != "completed" || payload["title"] != "Hi Ada" {
+		t.Errorf("expected delivered push job, got: %+v", job)
+	}
+
+	if data, _ := payload["payload"].(map[string]any); data["deepLink"] != "/welcome" {
+		t.Errorf("expected push data override, got: %v", payload["payload"])
+	}
+}
@@ -0,0 +1,3 @@
+// Package state contains the in-memory state of the server, such as seeded
+// environments and their resources.
+package state
@@ -0,0 +1,168 @@
+package state
+
+import (
+	"fmt"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// EnvironmentState contains an environment and all resources scoped to it.
+// Resources use the same shapes as the API responses.
+type EnvironmentState struct {
+	// Environment details, including its API keys.
+	Environment components.EnvironmentResponseDto `json:"environment"`
+
+	// Bridge application serving the code-first workflows of the
+	// environment, which are discovered when a workflow is synced.
+	Bridge *components.BridgeConfigurationDto `json:"bridge,omitempty"`
+
+	// Integrations configured in the environment.
+	Integrations []components.IntegrationResponseDto `json:"integrations,omitempty"`
+
+	// Layouts stored in the environment.
+	Layouts []components.LayoutResponseDto `json:"layouts,omitempty"`
+
+	// Notifications created by triggers, one per workflow run of a
+	// subscriber.
+	Notifications []components.ActivityNotificationResponseDto `json:"notifications,omitempty"`
+
+	// Simulated delivery outcomes of integrations in the environment.
+	Outcomes []IntegrationOutcome `json:"outcomes,omitempty"`
+
+	// Preferences of subscribers in the environment.
+	Preferences []SubscriberPreferences `json:"preferences,omitempty"`
+
+	// Subscribers stored in the environment.
+	Subscribers []components.SubscriberResponseDto `json:"subscribers,omitempty"`
+
+	// Tenants stored in the environment.
+	Tenants []Tenant `json:"tenants,omitempty"`
+
+	// Topics stored in the environment.
+	Topics []components.TopicResponseDto `json:"topics,omitempty"`
+
+	// Workflows stored in the environment.
+	Workflows []components.WorkflowResponseDto `json:"workflows,omitempty"`
+}
+
+// SubscriberPreferences associates preferences with a subscriber.
+type SubscriberPreferences struct {
+	// Subscriber identifier, which must match a subscriber in the same
+	// environment.
+	SubscriberID string `json:"subscriberId"`
+
+	// Global and per-workflow preferences of the subscriber.
+	Preferences components.GetSubscriberPreferencesDto `json:"preferences"`
+}
+
+// EnvironmentIndex returns the index of the environment with the database
+// identifier, or -1 if there is none.
+func EnvironmentIndex(environments []EnvironmentState, id string) int {
+	for i, environment := range environments {
+		if environment.Environment.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// EnvironmentIndexByAPIKey returns the index of the environment with the API
+// key, or -1 if there is none.
+func EnvironmentIndexByAPIKey(environments []EnvironmentState, key string) int {
+	if key == "" {
+		return -1
+	}
+
+	for i, environment := range environments {
+		for _, apiKey := range environment.Environment.APIKeys {
+			if apiKey.Key == key {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// UpdateEnvironment sets the name, identifier and bridge application given in
+// the request of the environment with the database identifier. Identifiers
+// used by another environment are refused. The parent, color and inbound
+// parse domain are not stored.
+func UpdateEnvironment(environments []EnvironmentState, id string, dto components.UpdateEnvironmentRequestDto) (*EnvironmentState, error) {
+	index := EnvironmentIndex(environments, id)
+
+	if index < 0 {
+		return nil, fmt.Errorf("environment %s: %w", id, ErrNotFound)
+	}
+
+	environment := &environments[index]
+
+	if dto.Identifier != nil && *dto.Identifier != environment.Environment.Identifier {
+		for _, other := range environments {
+			if other.Environment.Identifier == *dto.Identifier {
+				return nil, fmt.Errorf("environment with identifier %q already exists: %w", *dto.Identifier, ErrConflict)
+			}
+		}
+
+		environment.Environment.Identifier = *dto.Identifier
+	}
+
+	if dto.Name != nil {
+		environment.Environment.Name = *dto.Name
+	}
+
+	if dto.Bridge != nil {
+		environment.Bridge = dto.Bridge
+	}
+
+	return environment, nil
+}
+
+// DefaultLayoutIndex returns the index of the default layout, or -1 if there
+// is none.
+func (e *EnvironmentState) DefaultLayoutIndex() int {
+	for i, layout := range e.Layouts {
+		if layout.IsDefault {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// HasActiveIntegration returns whether the environment has an active
+// integration for the channel.
+func (e *EnvironmentState) HasActiveIntegration(channel components.IntegrationResponseDtoChannel) bool {
+	for _, integration := range e.Integrations {
+		if integration.Channel == channel && integration.Active && !integration.Deleted {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LayoutIndex returns the index of the layout with the layout identifier or
+// database identifier, or -1 if there is none.
+func (e *EnvironmentState) LayoutIndex(id string) int {
+	for i, layout := range e.Layouts {
+		if layout.LayoutID == id || layout.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// WorkflowIndex returns the index of the workflow with the workflow
+// identifier or database identifier, or -1 if there is none.
+func (e *EnvironmentState) WorkflowIndex(id string) int {
+	for i, workflow := range e.Workflows {
+		if workflow.WorkflowID == id || workflow.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,17 @@
+package state
+
+import (
+	"errors"
+)
+
+var (
+	// ErrConflict is wrapped by errors of changes that conflict with stored
+	// resources, such as duplicate names.
+	ErrConflict = errors.New("conflict")
+
+	// ErrInvalid is wrapped by errors of changes with invalid values.
+	ErrInvalid = errors.New("invalid")
+
+	// ErrNotFound is wrapped by errors of missing resources.
+	ErrNotFound = errors.New("not found")
+)
@@ -0,0 +1,298 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// TriggerEvent triggers the workflow named by dto for its recipients.
+// Subscriber recipients are created or updated from their payload, and
+// subscriber identifiers of unknown subscribers create them. Topic recipients
+// are accepted but not expanded, as topic subscriptions are not stored. Tenant
+// payloads are created or updated like subscribers, and so is the actor.
+//
+// Requests naming no workflow or recipients, an unknown workflow or an
+// unknown tenant identifier are refused with [ErrInvalid]. Inactive workflows and workflows without steps
+// are acknowledged with a trigger_not_active or no_workflow_steps_defined
+// status.
+func TriggerEvent(environment *EnvironmentState, dto components.TriggerEventRequestDto, now time.Time) (*components.TriggerEventResponseDto, error) {
+	index, err := triggerWorkflowIndex(environment, dto.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = triggerTenant(environment, dto.Tenant, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = triggerActor(environment, dto.Actor, now)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := triggerRecipients(environment, dto.To, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return trigger(environment, index, dto, recipients, now)
+}
+
+// TriggerBulk triggers each event independently via TriggerEvent. Refused
+// events result in an error status with the reason, without affecting the
+// other events.
+func TriggerBulk(environment *EnvironmentState, events []components.TriggerEventRequestDto, now time.Time) []components.TriggerEventResponseDto {
+	result := make([]components.TriggerEventResponseDto, len(events))
+
+	for i, event := range events {
+		response, err := TriggerEvent(environment, event, now)
+
+		if err != nil {
+			result[i] = components.TriggerEventResponseDto{
+				Acknowledged: false,
+				Status:       components.TriggerEventResponseDtoStatusError,
+				Error:        []string{err.Error()},
+			}
+
+			continue
+		}
+
+		result[i] = *response
+	}
+
+	return result
+}
+
+// BroadcastEvent triggers the workflow named by dto for all subscribers of the
+// environment, under a single transaction identifier.
+func BroadcastEvent(environment *EnvironmentState, dto components.TriggerEventToAllRequestDto, now time.Time) (*components.TriggerEventResponseDto, error) {
+	index, err := triggerWorkflowIndex(environment, dto.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := convert[components.TriggerEventRequestDto](dto)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = triggerTenant(environment, event.Tenant, now)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriberIDs := make([]string, 0, len(environment.Subscribers))
+
+	for _, subscriber := range environment.Subscribers {
+		if !subscriber.Deleted {
+			subscriberIDs = append(subscriberIDs, subscriber.SubscriberID)
+		}
+	}
+
+	// The actor is resolved after listing the recipients, so an actor created
+	// by the broadcast is not notified of it.
+	_, err = triggerActor(environment, event.Actor, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return trigger(environment, index, event, subscriberIDs, now)
+}
+
+// triggerWorkflowIndex returns the index of the triggered workflow.
+func triggerWorkflowIndex(environment *EnvironmentState, workflowID string) (int, error) {
+	if workflowID == "" {
+		return -1, fmt.Errorf("name should not be empty: %w", ErrInvalid)
+	}
+
+	index := environment.WorkflowIndex(workflowID)
+
+	if index < 0 {
+		return -1, fmt.Errorf("workflow_not_found: workflow %s does not exist: %w", workflowID, ErrInvalid)
+	}
+
+	return index, nil
+}
+
+// triggerActor creates or updates the subscriber of the actor of a trigger. It
+// returns nil without an actor.
+func triggerActor(environment *EnvironmentState, actor *components.TriggerEventRequestDtoActor, now time.Time) (*components.SubscriberResponseDto, error) {
+	if actor == nil {
+		return nil, nil
+	}
+
+	var dto components.CreateSubscriberRequestDto
+	var err error
+
+	switch actor.Type {
+	case components.TriggerEventRequestDtoActorTypeStr:
+		dto.SubscriberID = *actor.Str
+	case components.TriggerEventRequestDtoActorTypeSubscriberPayloadDto:
+		dto, err = subscriberPayload(*actor.SubscriberPayloadDto)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	subscriber, _, err := UpsertSubscriber(environment, dto, now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid actor: %w", err)
+	}
+
+	return subscriber, nil
+}
+
+// triggerRecipients returns the subscriber identifiers of the recipients,
+// creating or updating their subscribers.
+func triggerRecipients(environment *EnvironmentState, to components.ToUnion2, now time.Time) ([]string, error) {
+	var recipients []components.ToUnion1
+
+	switch to.Type {
+	case components.ToUnion2TypeArrayOfToUnion1:
+		recipients = to.ArrayOfToUnion1
+	case components.ToUnion2TypeStr:
+		recipients = []components.ToUnion1{components.CreateToUnion1Str(*to.Str)}
+	case components.ToUnion2TypeSubscriberPayloadDto:
+		recipients = []components.ToUnion1{components.CreateToUnion1SubscriberPayloadDto(*to.SubscriberPayloadDto)}
+	case components.ToUnion2TypeTopicPayloadDto:
+		recipients = []components.ToUnion1{components.CreateToUnion1TopicPayloadDto(*to.TopicPayloadDto)}
+	default:
+		return nil, fmt.Errorf("to should not be empty: %w", ErrInvalid)
+	}
+
+	var result []string
+
+	for _, recipient := range recipients {
+		var dto components.CreateSubscriberRequestDto
+		var err error
+
+		switch recipient.Type {
+		case components.ToUnion1TypeStr:
+			dto.SubscriberID = *recipient.Str
+		case components.ToUnion1TypeSubscriberPayloadDto:
+			dto, err = subscriberPayload(*recipient.SubscriberPayloadDto)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+
+		subscriber, _, err := UpsertSubscriber(environment, dto, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient: %w", err)
+		}
+
+		result = append(result, subscriber.SubscriberID)
+	}
+
+	return result, nil
+}
+
+// trigger triggers the workflow at index for the subscribers with the
+// subscriber identifiers, creating a notification for each subscriber.
+func trigger(environment *EnvironmentState, index int, dto components.TriggerEventRequestDto, subscriberIDs []string, now time.Time) (*components.TriggerEventResponseDto, error) {
+	workflow := &environment.Workflows[index]
+
+	if workflow.Status == components.WorkflowStatusEnumInactive {
+		return &components.TriggerEventResponseDto{
+			Acknowledged: true,
+			Status:       components.TriggerEventResponseDtoStatusTriggerNotActive,
+		}, nil
+	}
+
+	if len(workflow.Steps) == 0 {
+		return &components.TriggerEventResponseDto{
+			Acknowledged: true,
+			Status:       components.TriggerEventResponseDtoStatusNoWorkflowStepsDefined,
+		}, nil
+	}
+
+	transactionID := NewID()
+
+	if dto.TransactionID != nil && *dto.TransactionID != "" {
+		transactionID = *dto.TransactionID
+	}
+
+	lastTriggeredAt := FormatTime(now)
+	workflow.LastTriggeredAt = &lastTriggeredAt
+
+	for _, subscriberID := range subscriberIDs {
+		notification, err := newNotification(environment, workflow, subscriberID, dto, transactionID, now)
+		if err != nil {
+			return nil, err
+		}
+
+		environment.Notifications = append(environment.Notifications, notification)
+	}
+
+	return &components.TriggerEventResponseDto{
+		Acknowledged:  true,
+		Status:        components.TriggerEventResponseDtoStatusProcessed,
+		TransactionID: &transactionID,
+	}, nil
+}
+
+// newNotification returns the notification of a workflow run for the
+// subscriber with the subscriber identifier, with a delivered job per channel
+// step.
+func newNotification(environment *EnvironmentState, workflow *components.WorkflowResponseDto, subscriberID string, dto components.TriggerEventRequestDto, transactionID string, now time.Time) (components.ActivityNotificationResponseDto, error) {
+	id := NewID()
+	createdAt := FormatTime(now)
+	workflowDatabaseID := workflow.ID
+	origin := workflow.Origin
+	subscriber := environment.Subscribers[environment.SubscriberIndex(subscriberID)]
+
+	jobs, err := deliver(environment, workflow, &subscriber, dto, now)
+	if err != nil {
+		return components.ActivityNotificationResponseDto{}, err
+	}
+
+	channels := make([]components.StepTypeEnum, 0, len(workflow.Steps))
+
+	for _, step := range workflow.Steps {
+		if _, ok := stepChannel(&step); ok {
+			channels = append(channels, components.StepTypeEnum(step.Type))
+		}
+	}
+
+	return components.ActivityNotificationResponseDto{
+		ID:             &id,
+		EnvironmentID:  environment.Environment.ID,
+		OrganizationID: environment.Environment.OrganizationID,
+		SubscriberID:   *subscriber.ID,
+		TransactionID:  transactionID,
+		TemplateID:     &workflowDatabaseID,
+		CreatedAt:      &createdAt,
+		UpdatedAt:      &createdAt,
+		Channels:       channels,
+		Subscriber: &components.ActivityNotificationSubscriberResponseDto{
+			ID:           *subscriber.ID,
+			SubscriberID: subscriber.SubscriberID,
+			FirstName:    subscriber.FirstName,
+			LastName:     subscriber.LastName,
+			Email:        subscriber.Email,
+			Phone:        subscriber.Phone,
+		},
+		Template: &components.ActivityNotificationTemplateResponseDto{
+			ID:     &workflowDatabaseID,
+			Name:   workflow.Name,
+			Origin: &origin,
+			Triggers: []components.NotificationTriggerDto{
+				{
+					Type:       components.NotificationTriggerDtoTypeEvent,
+					Identifier: workflow.WorkflowID,
+					Variables:  []components.NotificationTriggerVariable{},
+				},
+			},
+		},
+		Jobs: jobs,
+		Tags: slices.Clone(workflow.Tags),
+	}, nil
+}
@@ -0,0 +1,171 @@
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestTriggerEvent(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	email := "bob@example.com"
+
+	result, err := TriggerEvent(dev, components.TriggerEventRequestDto{
+		WorkflowID: "welcome",
+		To: components.CreateToUnion2ArrayOfToUnion1([]components.ToUnion1{
+			components.CreateToUnion1Str("alice"),
+			components.CreateToUnion1SubscriberPayloadDto(components.SubscriberPayloadDto{SubscriberID: "bob", Email: &email}),
+			components.CreateToUnion1Str("carol"),
+		}),
+	}, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Status != components.TriggerEventResponseDtoStatusProcessed || !result.Acknowledged || result.TransactionID == nil {
+		t.Errorf("expected processed result with transaction, got: %+v", result)
+	}
+
+	if len(dev.Subscribers) != 3 || *dev.Subscribers[1].Email != email || dev.Subscribers[2].SubscriberID != "carol" {
+		t.Errorf("expected bob and carol to be created, got: %+v", dev.Subscribers)
+	}
+
+	if dev.Workflows[0].LastTriggeredAt == nil || *dev.Workflows[0].LastTriggeredAt != "2025-01-02T03:04:05.000Z" {
+		t.Errorf("expected lastTriggeredAt to be set, got: %v", dev.Workflows[0].LastTriggeredAt)
+	}
+
+	transactionID := "tx-1"
+
+	result, err = TriggerEvent(dev, components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice"), TransactionID: &transactionID}, now)
+
+	if err != nil || *result.TransactionID != transactionID {
+		t.Errorf("expected given transaction identifier, got: %+v, %v", result, err)
+	}
+
+	for _, test := range []struct {
+		name string
+		dto  components.TriggerEventRequestDto
+	}{
+		{name: "missing name", dto: components.TriggerEventRequestDto{To: components.CreateToUnion2Str("alice")}},
+		{name: "unknown workflow", dto: components.TriggerEventRequestDto{WorkflowID: "unknown", To: components.CreateToUnion2Str("alice")}},
+		{name: "missing to", dto: components.TriggerEventRequestDto{WorkflowID: "welcome"}},
+		{name: "empty subscriber", dto: components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("")}},
+	} {
+		if _, err := TriggerEvent(dev, test.dto, now); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected invalid error, got: %v", test.name, err)
+		}
+	}
+
+	dev.Workflows[0].Status = components.WorkflowStatusEnumInactive
+
+	if result, _ := TriggerEvent(dev, components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice")}, now); result.Status != components.TriggerEventResponseDtoStatusTriggerNotActive {
+		t.Errorf("expected trigger_not_active status, got: %+v", result)
+	}
+}
+
+func TestTriggerBulk(t *testing.T) {
+	dev, _ := testEnvironments(t)
+
+	results := TriggerBulk(dev, []components.TriggerEventRequestDto{
+		{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice")},
+		{WorkflowID: "unknown", To: components.CreateToUnion2Str("alice")},
+		{WorkflowID: "welcome", To: components.CreateToUnion2Str("bob")},
+	}, time.Now())
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got: %+v", results)
+	}
+
+	for i, expected := range []components.TriggerEventResponseDtoStatus{
+		components.TriggerEventResponseDtoStatusProcessed,
+		components.TriggerEventResponseDtoStatusError,
+		components.TriggerEventResponseDtoStatusProcessed,
+	} {
+		if results[i].Status != expected {
+			t.Errorf("event %d: expected status %s, got: %+v", i, expected, results[i])
+		}
+	}
+
+	if results[1].Acknowledged || len(results[1].Error) != 1 {
+		t.Errorf("expected unacknowledged error with reason, got: %+v", results[1])
+	}
+}
+
+func TestBroadcastEvent(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, subscriberID := range []string{"bob", "carol"} {
+		if _, _, err := UpsertSubscriber(dev, components.CreateSubscriberRequestDto{SubscriberID: subscriberID}, now); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	dev.Subscribers[2].Deleted = true
+
+	result, err := BroadcastEvent(dev, components.TriggerEventToAllRequestDto{Name: "welcome", Payload: map[string]any{}}, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.Status != components.TriggerEventResponseDtoStatusProcessed || result.TransactionID == nil {
+		t.Fatalf("expected processed result with transaction, got: %+v", result)
+	}
+
+	// Deleted subscribers are skipped.
+	if len(dev.Notifications) != 2 {
+		t.Fatalf("expected 2 notifications, got: %+v", dev.Notifications)
+	}
+
+	for i, subscriberID := range []string{"alice", "bob"} {
+		notification := dev.Notifications[i]
+
+		if notification.TransactionID != *result.TransactionID || notification.Subscriber.SubscriberID != subscriberID || notification.SubscriberID != *dev.Subscribers[i].ID {
+			t.Errorf("notification %d: expected %s in transaction %s, got: %+v", i, subscriberID, *result.TransactionID, notification)
+		}
+
+		if *notification.TemplateID != dev.Workflows[0].ID || len(notification.Channels) != 2 {
+			t.Errorf("notification %d: expected welcome workflow with 2 channels, got: %+v", i, notification)
+		}
+	}
+
+	if _, err := BroadcastEvent(dev, components.TriggerEventToAllRequestDto{Name: "unknown"}, now); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected invalid error, got: %v", err)
+	}
+}
+
+func TestTriggerEventActor(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	avatar := "https://example.com/dave.png"
+
+	for _, actor := range []components.TriggerEventRequestDtoActor{
+		components.CreateTriggerEventRequestDtoActorStr("carol"),
+		components.CreateTriggerEventRequestDtoActorSubscriberPayloadDto(components.SubscriberPayloadDto{SubscriberID: "dave", Avatar: &avatar}),
+	} {
+		_, err := TriggerEvent(dev, components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice"), Actor: &actor}, now)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if len(dev.Subscribers) != 3 || dev.Subscribers[1].SubscriberID != "carol" || *dev.Subscribers[2].Avatar != avatar {
+		t.Errorf("expected actors carol and dave to be stored, got: %+v", dev.Subscribers)
+	}
+
+	if len(dev.Notifications) != 2 || dev.Notifications[0].Subscriber.SubscriberID != "alice" || dev.Notifications[1].Subscriber.SubscriberID != "alice" {
+		t.Errorf("expected only alice to be notified, got: %+v", dev.Notifications)
+	}
+
+	actor := components.CreateTriggerEventRequestDtoActorStr("")
+
+	if _, err := TriggerEvent(dev, components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice"), Actor: &actor}, now); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected invalid error, got: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package state
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// TimeLayout is the format of timestamps in API responses.
+	TimeLayout = "2006-01-02T15:04:05.000Z"
+)
+
+// NewID returns a new random database identifier in the format of the API, a
+// 24 digit hexadecimal string.
+func NewID() string {
+	id := make([]byte, 12)
+
+	_, _ = rand.Read(id)
+
+	return hex.EncodeToString(id)
+}
+
+// FormatTime returns t as an API response timestamp.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(TimeLayout)
+}
+
+// Slugify returns the lowercase letters and digits of name, with each run of
+// other characters replaced by a hyphen.
+func Slugify(name string) string {
+	var result strings.Builder
+
+	hyphen := false
+
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if hyphen && result.Len() > 0 {
+				result.WriteByte('-')
+			}
+
+			result.WriteRune(r)
+			hyphen = false
+
+			continue
+		}
+
+		hyphen = true
+	}
+
+	return result.String()
+}
@@ -0,0 +1,216 @@
+package state
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+const (
+	// DefaultLayoutContent is the email content of created layouts. The
+	// {{content}} placeholder is replaced by the content of the email step.
+	DefaultLayoutContent = "{{content}}"
+)
+
+// LayoutListOptions filters, sorts and pages ListLayouts results.
+type LayoutListOptions struct {
+	// Maximum number of layouts returned.
+	Limit int
+
+	// Number of matching layouts skipped.
+	Offset int
+
+	// Sort field, by default createdAt.
+	OrderBy components.LayoutResponseDtoSortField
+
+	// Sort direction, by default descending.
+	OrderDirection components.DirectionEnum
+
+	// Case-insensitive substring of the name or layout identifier of returned
+	// layouts.
+	Query string
+}
+
+// CreateLayout adds a layout to the environment. The first layout of an
+// environment becomes its default layout.
+func CreateLayout(environment *EnvironmentState, dto components.CreateLayoutDto, now time.Time) (*components.LayoutResponseDto, error) {
+	if environment.LayoutIndex(dto.LayoutID) >= 0 {
+		return nil, fmt.Errorf("layout %s already exists: %w", dto.LayoutID, ErrConflict)
+	}
+
+	layout := components.LayoutResponseDto{
+		ID:        NewID(),
+		LayoutID:  dto.LayoutID,
+		Name:      dto.Name,
+		IsDefault: environment.DefaultLayoutIndex() < 0,
+		CreatedAt: FormatTime(now),
+		UpdatedAt: FormatTime(now),
+		Origin:    components.ResourceOriginEnumNovuCloud,
+		Type:      components.ResourceTypeEnumRegular,
+		Controls: components.LayoutControlsDto{
+			Values: components.LayoutControlValuesDto{
+				Email: &components.EmailControlsDto{
+					Content:    DefaultLayoutContent,
+					EditorType: components.EmailControlsDtoEditorTypeHTML,
+				},
+			},
+		},
+	}
+
+	environment.Layouts = append(environment.Layouts, layout)
+
+	return &layout, nil
+}
+
+// UpdateLayout replaces the name and control values of the layout with the
+// layout identifier or database identifier.
+func UpdateLayout(environment *EnvironmentState, id string, dto components.UpdateLayoutDto, now time.Time) (*components.LayoutResponseDto, error) {
+	index := environment.LayoutIndex(id)
+
+	if index < 0 {
+		return nil, fmt.Errorf("layout %s: %w", id, ErrNotFound)
+	}
+
+	layout := &environment.Layouts[index]
+	layout.Name = dto.Name
+	layout.Controls.Values = dto.ControlValues
+	layout.UpdatedAt = FormatTime(now)
+
+	return layout, nil
+}
+
+// DeleteLayout removes the layout with the layout identifier or database
+// identifier. Layouts used by an email step are refused, including the
+// default layout when a step does not reference a layout. When the default
+// layout is removed, the oldest remaining layout becomes the default.
+func DeleteLayout(environment *EnvironmentState, id string) error {
+	index := environment.LayoutIndex(id)
+
+	if index < 0 {
+		return fmt.Errorf("layout %s: %w", id, ErrNotFound)
+	}
+
+	layout := environment.Layouts[index]
+
+	for _, workflow := range environment.Workflows {
+		for i := range workflow.Steps {
+			step := &workflow.Steps[i]
+
+			if step.EmailStepResponseDto == nil {
+				continue
+			}
+
+			layoutID := stepLayoutID(step)
+
+			if layoutID == layout.LayoutID || layoutID == layout.ID || (layoutID == "" && layout.IsDefault) {
+				return fmt.Errorf("layout %s is used by step %s of workflow %s: %w", layout.LayoutID, stepString(step, "StepID"), workflow.WorkflowID, ErrConflict)
+			}
+		}
+	}
+
+	environment.Layouts = slices.Delete(environment.Layouts, index, index+1)
+
+	if layout.IsDefault && len(environment.Layouts) > 0 {
+		oldest := slices.MinFunc(environment.Layouts, func(a, b components.LayoutResponseDto) int {
+			return strings.Compare(a.CreatedAt, b.CreatedAt)
+		})
+
+		environment.Layouts[environment.LayoutIndex(oldest.ID)].IsDefault = true
+	}
+
+	return nil
+}
+
+// DuplicateLayout copies the layout with the layout identifier or database
+// identifier under a new layout identifier, derived from the original with a
+// -copy suffix. The copy is named name, or by default the original name with a
+// (copy) suffix, and is never the default layout.
+func DuplicateLayout(environment *EnvironmentState, id string, name string, now time.Time) (*components.LayoutResponseDto, error) {
+	index := environment.LayoutIndex(id)
+
+	if index < 0 {
+		return nil, fmt.Errorf("layout %s: %w", id, ErrNotFound)
+	}
+
+	layout, err := clone(environment.Layouts[index])
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = layout.Name + " (copy)"
+	}
+
+	layoutID := layout.LayoutID + "-copy"
+
+	for i := 2; environment.LayoutIndex(layoutID) >= 0; i++ {
+		layoutID = fmt.Sprintf("%s-copy-%d", layout.LayoutID, i)
+	}
+
+	layout.ID = NewID()
+	layout.LayoutID = layoutID
+	layout.Name = name
+	layout.IsDefault = false
+	layout.CreatedAt = FormatTime(now)
+	layout.UpdatedAt = FormatTime(now)
+
+	environment.Layouts = append(environment.Layouts, layout)
+
+	return &layout, nil
+}
+
+// ListLayouts returns the page of layouts matching the options and the total
+// number of matching layouts.
+func ListLayouts(environment *EnvironmentState, options LayoutListOptions) ([]components.LayoutResponseDto, int) {
+	query := strings.ToLower(options.Query)
+	result := make([]components.LayoutResponseDto, 0, len(environment.Layouts))
+
+	for _, layout := range environment.Layouts {
+		if strings.Contains(strings.ToLower(layout.Name), query) || strings.Contains(strings.ToLower(layout.LayoutID), query) {
+			result = append(result, layout)
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b components.LayoutResponseDto) int {
+		var order int
+
+		switch options.OrderBy {
+		case components.LayoutResponseDtoSortFieldName:
+			order = cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		case components.LayoutResponseDtoSortFieldUpdatedAt:
+			order = cmp.Compare(a.UpdatedAt, b.UpdatedAt)
+		default:
+			order = cmp.Compare(a.CreatedAt, b.CreatedAt)
+		}
+
+		if options.OrderDirection != components.DirectionEnumAsc {
+			order = -order
+		}
+
+		return order
+	})
+
+	total := len(result)
+
+	return page(result, options.Offset, options.Limit), total
+}
+
+// page returns the items after offset, up to limit items. A limit of zero
+// returns all remaining items.
+func page[T any](items []T, offset int, limit int) []T {
+	if offset >= len(items) {
+		return []T{}
+	}
+
+	items = items[offset:]
+
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+
+	return items
+}
@@ -0,0 +1,113 @@
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestLayouts(t *testing.T) {
+	dev, prod := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// The first layout of an environment becomes the default.
+	first, err := CreateLayout(prod, components.CreateLayoutDto{LayoutID: "brand", Name: "Brand"}, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	second, err := CreateLayout(prod, components.CreateLayoutDto{LayoutID: "alert", Name: "Alert"}, now.Add(time.Minute))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !first.IsDefault || second.IsDefault {
+		t.Errorf("expected only the first layout to be default, got: %t, %t", first.IsDefault, second.IsDefault)
+	}
+
+	if _, err := CreateLayout(prod, components.CreateLayoutDto{LayoutID: "brand", Name: "Brand"}, now); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+
+	duplicate, err := DuplicateLayout(prod, "brand", "", now.Add(2*time.Minute))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if duplicate.LayoutID != "brand-copy" || duplicate.Name != "Brand (copy)" || duplicate.IsDefault || duplicate.ID == first.ID {
+		t.Errorf("unexpected duplicate: %+v", duplicate)
+	}
+
+	if duplicate, _ := DuplicateLayout(prod, "brand", "Brand 2", now.Add(3*time.Minute)); duplicate.LayoutID != "brand-copy-2" || duplicate.Name != "Brand 2" {
+		t.Errorf("unexpected second duplicate: %+v", duplicate)
+	}
+
+	for _, test := range []struct {
+		name     string
+		options  LayoutListOptions
+		expected []string
+		total    int
+	}{
+		{name: "default", expected: []string{"brand-copy-2", "brand-copy", "alert", "brand"}, total: 4},
+		{name: "name ascending", options: LayoutListOptions{OrderBy: components.LayoutResponseDtoSortFieldName, OrderDirection: components.DirectionEnumAsc}, expected: []string{"alert", "brand", "brand-copy", "brand-copy-2"}, total: 4},
+		{name: "query", options: LayoutListOptions{Query: "COPY"}, expected: []string{"brand-copy-2", "brand-copy"}, total: 2},
+		{name: "page", options: LayoutListOptions{Limit: 1, Offset: 1, OrderDirection: components.DirectionEnumAsc}, expected: []string{"alert"}, total: 4},
+		{name: "offset past end", options: LayoutListOptions{Offset: 10}, expected: []string{}, total: 4},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			layouts, total := ListLayouts(prod, test.options)
+
+			var got []string
+
+			for _, layout := range layouts {
+				got = append(got, layout.LayoutID)
+			}
+
+			if total != test.total || len(got) != len(test.expected) {
+				t.Fatalf("expected %v of %d, got: %v of %d", test.expected, test.total, got, total)
+			}
+
+			for i := range got {
+				if got[i] != test.expected[i] {
+					t.Errorf("expected %v, got: %v", test.expected, got)
+				}
+			}
+		})
+	}
+
+	// Deleting the default layout promotes the oldest remaining one.
+	if err := DeleteLayout(prod, first.ID); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if prod.DefaultLayoutIndex() != prod.LayoutIndex("alert") {
+		t.Errorf("expected alert to become the default layout, got: %+v", prod.Layouts)
+	}
+
+	// The development layout is referenced by the email step.
+	if err := DeleteLayout(dev, "default-layout"); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+
+	// Steps without a layout reference use the default layout.
+	dev.Workflows[0].Steps[1].EmailStepResponseDto.ControlValues.LayoutID = nil
+
+	if err := DeleteLayout(dev, "default-layout"); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected conflict error, got: %v", err)
+	}
+
+	dev.Workflows = nil
+
+	if err := DeleteLayout(dev, "layout-dev-default"); err != nil || len(dev.Layouts) != 0 {
+		t.Errorf("expected layout to be deleted, got: %v, %+v", err, dev.Layouts)
+	}
+
+	if err := DeleteLayout(dev, "default-layout"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
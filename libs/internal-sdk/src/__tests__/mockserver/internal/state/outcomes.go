@@ -0,0 +1,214 @@
+package state
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+const (
+	// OutcomeSucceed delivers messages of the integration, which is the
+	// default outcome.
+	OutcomeSucceed = "succeed"
+
+	// OutcomePermanentFailure fails every delivery attempt of the
+	// integration, failing over to the next active integration of the
+	// channel.
+	OutcomePermanentFailure = "permanent_failure"
+
+	// OutcomeTransientFailure fails the first delivery attempts of the
+	// integration, after which the retried delivery succeeds.
+	OutcomeTransientFailure = "transient_failure"
+
+	// OutcomeDelay delivers messages of the integration after a delay.
+	OutcomeDelay = "delay"
+
+	// Maximum number of delivery attempts per integration and job.
+	maxDeliveryAttempts = 3
+
+	// Provider error message of failed attempts without a configured
+	// message.
+	defaultOutcomeMessage = "Simulated provider error"
+)
+
+// IntegrationOutcome is the simulated result of delivering messages through
+// an integration, such as a provider outage.
+type IntegrationOutcome struct {
+	// Database identifier of the integration.
+	IntegrationID string `json:"integrationId"`
+
+	// Outcome of delivery attempts, such as succeed or permanent_failure.
+	Outcome string `json:"outcome"`
+
+	// Number of failing attempts of a transient_failure outcome, by default
+	// 1. Deliveries fail over once all attempts of a job have failed.
+	Failures int `json:"failures,omitempty"`
+
+	// Delay in milliseconds of a delay outcome, added to the time of the
+	// delivery. Requests are not held for the delay.
+	DelayMs int `json:"delayMs,omitempty"`
+
+	// Provider error message of failed attempts.
+	Message string `json:"message,omitempty"`
+}
+
+// Validate returns an error if the outcome is unknown or its options do not
+// apply to it.
+func (o IntegrationOutcome) Validate() error {
+	if o.IntegrationID == "" {
+		return fmt.Errorf("integrationId: required: %w", ErrInvalid)
+	}
+
+	switch o.Outcome {
+	case OutcomeSucceed, OutcomePermanentFailure, OutcomeTransientFailure, OutcomeDelay:
+	default:
+		return fmt.Errorf("outcome: unknown outcome %q, expected %s, %s, %s or %s: %w", o.Outcome, OutcomeSucceed, OutcomePermanentFailure, OutcomeTransientFailure, OutcomeDelay, ErrInvalid)
+	}
+
+	if o.Failures < 0 || (o.Failures > 0 && o.Outcome != OutcomeTransientFailure) {
+		return fmt.Errorf("failures: must be positive and only set for %s: %w", OutcomeTransientFailure, ErrInvalid)
+	}
+
+	if o.DelayMs < 0 || (o.DelayMs > 0 && o.Outcome != OutcomeDelay) {
+		return fmt.Errorf("delayMs: must be positive and only set for %s: %w", OutcomeDelay, ErrInvalid)
+	}
+
+	return nil
+}
+
+// SetIntegrationOutcome configures the outcome of the integration with the
+// database identifier in any of the environments, replacing its previous
+// outcome. A succeed outcome removes the configuration.
+func SetIntegrationOutcome(environments []EnvironmentState, outcome IntegrationOutcome) error {
+	err := outcome.Validate()
+	if err != nil {
+		return err
+	}
+
+	for i := range environments {
+		environment := &environments[i]
+
+		if environment.IntegrationIndex(outcome.IntegrationID) < 0 {
+			continue
+		}
+
+		index := environment.OutcomeIndex(outcome.IntegrationID)
+
+		switch {
+		case outcome.Outcome == OutcomeSucceed && index >= 0:
+			environment.Outcomes = slices.Delete(environment.Outcomes, index, index+1)
+		case outcome.Outcome == OutcomeSucceed:
+		case index >= 0:
+			environment.Outcomes[index] = outcome
+		default:
+			environment.Outcomes = append(environment.Outcomes, outcome)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("integration %s: %w", outcome.IntegrationID, ErrNotFound)
+}
+
+// IntegrationIndex returns the index of the integration with the database
+// identifier, or -1 if there is none.
+func (e *EnvironmentState) IntegrationIndex(id string) int {
+	for i, integration := range e.Integrations {
+		if integration.ID != nil && *integration.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// OutcomeIndex returns the index of the outcome configured for the
+// integration with the database identifier, or -1 if there is none.
+func (e *EnvironmentState) OutcomeIndex(integrationID string) int {
+	for i, outcome := range e.Outcomes {
+		if outcome.IntegrationID == integrationID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// integrationOutcome returns the outcome configured for the integration, by
+// default succeed. A nil integration, such as the default in-app provider,
+// always succeeds.
+func (e *EnvironmentState) integrationOutcome(integration *components.IntegrationResponseDto) IntegrationOutcome {
+	if integration != nil && integration.ID != nil {
+		if index := e.OutcomeIndex(*integration.ID); index >= 0 {
+			return e.Outcomes[index]
+		}
+	}
+
+	return IntegrationOutcome{Outcome: OutcomeSucceed}
+}
+
+// attemptDelivery returns the execution details of delivering the raw
+// provider payload through the provider with the outcome, and whether the
+// message was sent.
+func attemptDelivery(outcome IntegrationOutcome, providerID string, raw string, now time.Time) ([]components.ActivityNotificationExecutionDetailResponseDto, bool) {
+	message := outcome.Message
+
+	if message == "" {
+		message = defaultOutcomeMessage
+	}
+
+	switch outcome.Outcome {
+	case OutcomePermanentFailure:
+		return []components.ActivityNotificationExecutionDetailResponseDto{
+			executionDetail(providerID, components.ExecutionDetailsStatusEnumFailed, fmt.Sprintf("Provider %s returned a permanent error: %s", providerID, message), &raw, now),
+		}, false
+	case OutcomeTransientFailure:
+		failures := max(outcome.Failures, 1)
+
+		var result []components.ActivityNotificationExecutionDetailResponseDto
+
+		for attempt := 1; attempt <= min(failures, maxDeliveryAttempts); attempt++ {
+			status := components.ExecutionDetailsStatusEnumWarning
+
+			if attempt == maxDeliveryAttempts {
+				status = components.ExecutionDetailsStatusEnumFailed
+			}
+
+			result = append(result, executionDetail(providerID, status, fmt.Sprintf("Provider %s returned a transient error on attempt %d of %d: %s", providerID, attempt, maxDeliveryAttempts, message), &raw, now))
+		}
+
+		if failures >= maxDeliveryAttempts {
+			return result, false
+		}
+
+		return append(result, executionDetail(providerID, components.ExecutionDetailsStatusEnumSuccess, "Message sent", &raw, now)), true
+	case OutcomeDelay:
+		delay := time.Duration(outcome.DelayMs) * time.Millisecond
+
+		return []components.ActivityNotificationExecutionDetailResponseDto{
+			executionDetail(providerID, components.ExecutionDetailsStatusEnumSuccess, fmt.Sprintf("Message sent after %s", delay), &raw, now.Add(delay)),
+		}, true
+	default:
+		return []components.ActivityNotificationExecutionDetailResponseDto{
+			executionDetail(providerID, components.ExecutionDetailsStatusEnumSuccess, "Message sent", &raw, now),
+		}, true
+	}
+}
+
+// executionDetail returns an execution detail of a delivery attempt at the
+// time.
+func executionDetail(providerID string, status components.ExecutionDetailsStatusEnum, detail string, raw *string, at time.Time) components.ActivityNotificationExecutionDetailResponseDto {
+	timestamp := FormatTime(at)
+
+	return components.ActivityNotificationExecutionDetailResponseDto{
+		ID:         NewID(),
+		CreatedAt:  &timestamp,
+		Status:     status,
+		Detail:     detail,
+		Source:     components.ExecutionDetailsSourceEnumInternal,
+		ProviderID: components.ProvidersIDEnum(providerID),
+		Raw:        raw,
+	}
+}
@@ -0,0 +1,165 @@
+package state
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestIntegrationOutcomes(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	secondaryID := "int-dev-email-2"
+
+	for _, test := range []struct {
+		name       string
+		outcomes   []IntegrationOutcome
+		status     string
+		providerID components.ProvidersIDEnum
+		details    []components.ExecutionDetailsStatusEnum
+		updatedAt  string
+	}{
+		{
+			name:       "succeed",
+			status:     "completed",
+			providerID: "sendgrid",
+			details:    []components.ExecutionDetailsStatusEnum{"Success"},
+			updatedAt:  "2025-01-02T03:04:05.000Z",
+		},
+		{
+			name:       "transient failure retried",
+			outcomes:   []IntegrationOutcome{{IntegrationID: "int-dev-email", Outcome: OutcomeTransientFailure, Failures: 2}},
+			status:     "completed",
+			providerID: "sendgrid",
+			details:    []components.ExecutionDetailsStatusEnum{"Warning", "Warning", "Success"},
+			updatedAt:  "2025-01-02T03:04:05.000Z",
+		},
+		{
+			name:       "transient failure exhausted fails over",
+			outcomes:   []IntegrationOutcome{{IntegrationID: "int-dev-email", Outcome: OutcomeTransientFailure, Failures: 5}},
+			status:     "completed",
+			providerID: "mailgun",
+			details:    []components.ExecutionDetailsStatusEnum{"Warning", "Warning", "Failed", "Success"},
+			updatedAt:  "2025-01-02T03:04:05.000Z",
+		},
+		{
+			name:       "permanent failure fails over",
+			outcomes:   []IntegrationOutcome{{IntegrationID: "int-dev-email", Outcome: OutcomePermanentFailure}},
+			status:     "completed",
+			providerID: "mailgun",
+			details:    []components.ExecutionDetailsStatusEnum{"Failed", "Success"},
+			updatedAt:  "2025-01-02T03:04:05.000Z",
+		},
+		{
+			name: "all integrations fail",
+			outcomes: []IntegrationOutcome{
+				{IntegrationID: "int-dev-email", Outcome: OutcomePermanentFailure},
+				{IntegrationID: secondaryID, Outcome: OutcomePermanentFailure, Message: "Invalid API key"},
+			},
+			status:     "failed",
+			providerID: "mailgun",
+			details:    []components.ExecutionDetailsStatusEnum{"Failed", "Failed"},
+			updatedAt:  "2025-01-02T03:04:05.000Z",
+		},
+		{
+			name:       "delay",
+			outcomes:   []IntegrationOutcome{{IntegrationID: "int-dev-email", Outcome: OutcomeDelay, DelayMs: 2000}},
+			status:     "completed",
+			providerID: "sendgrid",
+			details:    []components.ExecutionDetailsStatusEnum{"Success"},
+			updatedAt:  "2025-01-02T03:04:07.000Z",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dev, _ := testEnvironments(t)
+
+			dev.Integrations = append(dev.Integrations, components.IntegrationResponseDto{
+				ID:         &secondaryID,
+				Identifier: "mailgun",
+				ProviderID: "mailgun",
+				Channel:    components.IntegrationResponseDtoChannelEmail,
+				Active:     true,
+			})
+
+			environments := []EnvironmentState{*dev}
+			dev = &environments[0]
+
+			for _, outcome := range test.outcomes {
+				err := SetIntegrationOutcome(environments, outcome)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+			}
+
+			_, err := TriggerEvent(dev, components.TriggerEventRequestDto{
+				WorkflowID: "welcome",
+				To:         components.CreateToUnion2Str("alice"),
+			}, now)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			job, _ := deliveredPayload(t, dev, components.ActivityNotificationJobResponseDtoTypeEmail)
+
+			if job.Status != test.status || job.ProviderID != test.providerID {
+				t.Errorf("expected %s %s job, got: %s %s", test.status, test.providerID, job.Status, job.ProviderID)
+			}
+
+			var details []components.ExecutionDetailsStatusEnum
+
+			for _, detail := range job.ExecutionDetails {
+				details = append(details, detail.Status)
+			}
+
+			if !slices.Equal(details, test.details) {
+				t.Errorf("expected execution details %v, got: %v", test.details, details)
+			}
+
+			if job.UpdatedAt == nil || *job.UpdatedAt != test.updatedAt {
+				t.Errorf("expected updatedAt %s, got: %v", test.updatedAt, job.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestSetIntegrationOutcome(t *testing.T) {
+	dev, prod := testEnvironments(t)
+	environments := []EnvironmentState{*dev, *prod}
+
+	err := SetIntegrationOutcome(environments, IntegrationOutcome{IntegrationID: "int-dev-email", Outcome: OutcomeDelay, DelayMs: 100})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(environments[0].Outcomes) != 1 || environments[0].Outcomes[0].DelayMs != 100 {
+		t.Errorf("expected delay outcome, got: %+v", environments[0].Outcomes)
+	}
+
+	err = SetIntegrationOutcome(environments, IntegrationOutcome{IntegrationID: "int-dev-email", Outcome: OutcomeSucceed})
+
+	if err != nil || len(environments[0].Outcomes) != 0 {
+		t.Errorf("expected succeed to remove the outcome, got: %v, %+v", err, environments[0].Outcomes)
+	}
+
+	for _, test := range []struct {
+		outcome  IntegrationOutcome
+		expected error
+	}{
+		{outcome: IntegrationOutcome{IntegrationID: "int-unknown", Outcome: OutcomeSucceed}, expected: ErrNotFound},
+		{outcome: IntegrationOutcome{IntegrationID: "int-dev-email", Outcome: "timeout"}, expected: ErrInvalid},
+		{outcome: IntegrationOutcome{IntegrationID: "int-dev-email", Outcome: OutcomeSucceed, DelayMs: 100}, expected: ErrInvalid},
+		{outcome: IntegrationOutcome{IntegrationID: "int-dev-email", Outcome: OutcomeDelay, Failures: 1}, expected: ErrInvalid},
+		{outcome: IntegrationOutcome{Outcome: OutcomeSucceed}, expected: ErrInvalid},
+	} {
+		err := SetIntegrationOutcome(environments, test.outcome)
+
+		if !errors.Is(err, test.expected) {
+			t.Errorf("%+v: expected %v, got: %v", test.outcome, test.expected, err)
+		}
+	}
+}
@@ -0,0 +1,415 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/sdk/utils"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// JSON seed format.
+	SeedFormatJSON = "JSON"
+
+	// YAML seed format.
+	SeedFormatYAML = "YAML"
+)
+
+// Seed is a declarative document describing the initial state of the server.
+type Seed struct {
+	// Environments and their resources.
+	Environments []EnvironmentState `json:"environments"`
+}
+
+// rawSeed is the undecoded form of Seed, which allows each entity to be
+// validated individually so errors can name the offending entity path.
+type rawSeed struct {
+	Environments []rawEnvironmentState `json:"environments"`
+}
+
+// rawEnvironmentState is the undecoded form of EnvironmentState.
+type rawEnvironmentState struct {
+	Environment   json.RawMessage   `json:"environment"`
+	Bridge        json.RawMessage   `json:"bridge"`
+	Integrations  []json.RawMessage `json:"integrations"`
+	Layouts       []json.RawMessage `json:"layouts"`
+	Notifications []json.RawMessage `json:"notifications"`
+	Outcomes      []json.RawMessage `json:"outcomes"`
+	Preferences   []json.RawMessage `json:"preferences"`
+	Subscribers   []json.RawMessage `json:"subscribers"`
+	Tenants       []json.RawMessage `json:"tenants"`
+	Topics        []json.RawMessage `json:"topics"`
+	Workflows     []json.RawMessage `json:"workflows"`
+}
+
+// ReadSeedFile reads and validates a seed file. Files with a .yaml or .yml
+// extension are decoded as YAML, all others as JSON.
+func ReadSeedFile(path string) (*Seed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading seed file (%s): %w", path, err)
+	}
+
+	format := SeedFormatJSON
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = SeedFormatYAML
+	}
+
+	result, err := ParseSeed(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("error loading seed file (%s): %w", path, err)
+	}
+
+	return result, nil
+}
+
+// ParseSeed decodes and validates a seed document in the given format. Each
+// entity is decoded via [utils.UnmarshalJSON] with unknown fields disallowed.
+func ParseSeed(data []byte, format string) (*Seed, error) {
+	switch format {
+	case SeedFormatJSON:
+	case SeedFormatYAML:
+		jsonData, err := yamlToJSON(data)
+		if err != nil {
+			return nil, err
+		}
+
+		data = jsonData
+	default:
+		return nil, fmt.Errorf("unsupported seed format (%s), supported formats: %s, %s", format, SeedFormatJSON, SeedFormatYAML)
+	}
+
+	var raw rawSeed
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding seed: %w", err)
+	}
+
+	environments, err := parseEnvironmentStates(raw.Environments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed: %w", err)
+	}
+
+	return &Seed{Environments: environments}, nil
+}
+
+// parseEnvironmentStates decodes and validates all environments, verifying
+// environment identifiers are unique.
+func parseEnvironmentStates(raws []rawEnvironmentState) ([]EnvironmentState, error) {
+	result := make([]EnvironmentState, 0, len(raws))
+	identifiers := make(map[string]string, len(raws))
+
+	for i, raw := range raws {
+		path := fmt.Sprintf("environments[%d]", i)
+
+		environment, err := parseEnvironmentState(path, raw)
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkUnique(identifiers, path+".environment.identifier", environment.Environment.Identifier)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *environment)
+	}
+
+	return result, nil
+}
+
+// parseEnvironmentState decodes and validates a single environment and its
+// resources.
+func parseEnvironmentState(path string, raw rawEnvironmentState) (*EnvironmentState, error) {
+	var result EnvironmentState
+	var err error
+
+	if len(raw.Environment) == 0 {
+		return nil, fmt.Errorf("%s.environment: required", path)
+	}
+
+	err = unmarshalEntity(path+".environment", raw.Environment, &result.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Environment.Identifier == "" {
+		return nil, fmt.Errorf("%s.environment.identifier: required", path)
+	}
+
+	if len(raw.Bridge) > 0 {
+		result.Bridge = &components.BridgeConfigurationDto{}
+
+		err = unmarshalEntity(path+".bridge", raw.Bridge, result.Bridge)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Integrations, err = parseEntities(path+".integrations", raw.Integrations, "identifier", func(v *components.IntegrationResponseDto) string { return v.Identifier })
+	if err != nil {
+		return nil, err
+	}
+
+	result.Layouts, err = parseEntities(path+".layouts", raw.Layouts, "layoutId", func(v *components.LayoutResponseDto) string { return v.LayoutID })
+	if err != nil {
+		return nil, err
+	}
+
+	result.Notifications, err = parseEntities(path+".notifications", raw.Notifications, "_id", func(v *components.ActivityNotificationResponseDto) string {
+		if v.ID == nil {
+			return ""
+		}
+
+		return *v.ID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Outcomes, err = parseEntities(path+".outcomes", raw.Outcomes, "integrationId", func(v *IntegrationOutcome) string { return v.IntegrationID })
+	if err != nil {
+		return nil, err
+	}
+
+	for i, outcome := range result.Outcomes {
+		err = outcome.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("%s.outcomes[%d].%w", path, i, err)
+		}
+
+		if result.IntegrationIndex(outcome.IntegrationID) < 0 {
+			return nil, fmt.Errorf("%s.outcomes[%d].integrationId: unknown integration %q", path, i, outcome.IntegrationID)
+		}
+	}
+
+	result.Subscribers, err = parseEntities(path+".subscribers", raw.Subscribers, "subscriberId", func(v *components.SubscriberResponseDto) string { return v.SubscriberID })
+	if err != nil {
+		return nil, err
+	}
+
+	result.Tenants, err = parseEntities(path+".tenants", raw.Tenants, "identifier", func(v *Tenant) string { return v.Identifier })
+	if err != nil {
+		return nil, err
+	}
+
+	result.Topics, err = parseEntities(path+".topics", raw.Topics, "key", func(v *components.TopicResponseDto) string { return v.Key })
+	if err != nil {
+		return nil, err
+	}
+
+	result.Workflows, err = parseEntities(path+".workflows", raw.Workflows, "workflowId", func(v *components.WorkflowResponseDto) string { return v.WorkflowID })
+	if err != nil {
+		return nil, err
+	}
+
+	result.Preferences, err = parseEntities(path+".preferences", raw.Preferences, "subscriberId", func(v *SubscriberPreferences) string { return v.SubscriberID })
+	if err != nil {
+		return nil, err
+	}
+
+	for i, preferences := range result.Preferences {
+		found := false
+
+		for _, subscriber := range result.Subscribers {
+			if subscriber.SubscriberID == preferences.SubscriberID {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%s.preferences[%d].subscriberId: unknown subscriber %q", path, i, preferences.SubscriberID)
+		}
+	}
+
+	return &result, nil
+}
+
+// parseEntities decodes each raw entity and verifies the identifier returned
+// by key is set and unique within the list.
+func parseEntities[T any](path string, raws []json.RawMessage, keyName string, key func(*T) string) ([]T, error) {
+	if len(raws) == 0 {
+		return nil, nil
+	}
+
+	result := make([]T, len(raws))
+	seen := make(map[string]string, len(raws))
+
+	for i, raw := range raws {
+		entityPath := fmt.Sprintf("%s[%d]", path, i)
+
+		err := unmarshalEntity(entityPath, raw, &result[i])
+		if err != nil {
+			return nil, err
+		}
+
+		err = checkUnique(seen, entityPath+"."+keyName, key(&result[i]))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// unmarshalEntity decodes a single entity via [utils.UnmarshalJSON] and
+// prefixes any error with the entity path. Fields required by the entity
+// type must be present, as the decoder would otherwise leave them empty.
+func unmarshalEntity(path string, raw json.RawMessage, v any) error {
+	err := utils.UnmarshalJSON(raw, v, "", false, true)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return checkRequiredFields(path, raw, reflect.TypeOf(v))
+}
+
+// checkRequiredFields verifies the JSON object raw has every field required by
+// t, recursing into nested objects and arrays of objects. Required fields are
+// those of a non-pointer type without omitempty or a default value. Unions,
+// whose variants are tagged queryParam:"inline", are not checked.
+func checkRequiredFields(path string, raw json.RawMessage, t reflect.Type) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("queryParam") == "inline" {
+			return nil
+		}
+	}
+
+	var fields map[string]json.RawMessage
+
+	// Values which are not objects fail to decode before they are checked.
+	if json.Unmarshal(raw, &fields) != nil {
+		return nil
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		fieldPath := path + "." + name
+		value, ok := fields[name]
+
+		if !ok || string(value) == "null" {
+			if field.Type.Kind() != reflect.Pointer && !strings.Contains(options, "omitempty") && field.Tag.Get("default") == "" {
+				return fmt.Errorf("%s: required", fieldPath)
+			}
+
+			continue
+		}
+
+		if field.Type.Kind() != reflect.Slice {
+			err := checkRequiredFields(fieldPath, value, field.Type)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		var items []json.RawMessage
+
+		if json.Unmarshal(value, &items) != nil {
+			continue
+		}
+
+		for j, item := range items {
+			err := checkRequiredFields(fmt.Sprintf("%s[%d]", fieldPath, j), item, field.Type.Elem())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkUnique verifies value is set and was not previously seen, then records
+// it against path.
+func checkUnique(seen map[string]string, path string, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s: required", path)
+	}
+
+	if priorPath, ok := seen[value]; ok {
+		return fmt.Errorf("%s: duplicate value %q, also used by %s", path, value, priorPath)
+	}
+
+	seen[value] = path
+
+	return nil
+}
+
+// yamlToJSON converts a YAML document into JSON so it can be decoded like a
+// JSON seed.
+func yamlToJSON(data []byte) ([]byte, error) {
+	var value any
+
+	err := yaml.Unmarshal(data, &value)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding seed YAML: %w", err)
+	}
+
+	result, err := json.Marshal(jsonCompatible(value))
+	if err != nil {
+		return nil, fmt.Errorf("error converting seed YAML to JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// jsonCompatible recursively converts YAML mappings with non-string keys into
+// maps which [json.Marshal] supports.
+func jsonCompatible(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = jsonCompatible(item)
+		}
+
+		return v
+	case map[any]any:
+		result := make(map[string]any, len(v))
+
+		for key, item := range v {
+			result[fmt.Sprint(key)] = jsonCompatible(item)
+		}
+
+		return result
+	case []any:
+		for i, item := range v {
+			v[i] = jsonCompatible(item)
+		}
+
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,167 @@
+package state
+
+import (
+	"strings"
+	"testing"
+)
+
+// seedEnvironment is a minimal valid JSON environment with the identifier.
+func seedEnvironment(identifier string, resources string) string {
+	result := `{"environment":{"_id":"env-` + identifier + `","name":"` + identifier + `","_organizationId":"org","identifier":"` + identifier + `","apiKeys":[{"key":"` + identifier + `-key","_userId":"user"}]}`
+
+	if resources != "" {
+		result += "," + resources
+	}
+
+	return result + "}"
+}
+
+// seedSubscriber is a minimal valid JSON subscriber with the identifier.
+func seedSubscriber(subscriberID string) string {
+	return `{"subscriberId":"` + subscriberID + `","_organizationId":"org","_environmentId":"env","deleted":false,"createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z"}`
+}
+
+func TestParseSeed(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		format   string
+		data     string
+		expected string
+	}{
+		{
+			name:   "JSON",
+			format: SeedFormatJSON,
+			data:   `{"environments":[` + seedEnvironment("dev", `"subscribers":[`+seedSubscriber("alice")+`],"preferences":[{"subscriberId":"alice","preferences":{"global":{"enabled":true,"channels":{}},"workflows":[]}}]`) + `]}`,
+		},
+		{
+			name:   "YAML",
+			format: SeedFormatYAML,
+			data: `
+environments:
+  - environment:
+      _id: env-dev
+      name: Development
+      _organizationId: org
+      identifier: dev
+    bridge:
+      url: http://localhost:4000/api/novu
+    tenants:
+      - identifier: acme
+`,
+		},
+		{
+			name:     "unsupported format",
+			format:   "TOML",
+			data:     ``,
+			expected: "unsupported seed format (TOML)",
+		},
+		{
+			name:     "missing environment",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[{}]}`,
+			expected: "invalid seed: environments[0].environment: required",
+		},
+		{
+			name:     "missing environment _id",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[{"environment":{"name":"dev","_organizationId":"org","identifier":"dev"}}]}`,
+			expected: "invalid seed: environments[0].environment._id: required",
+		},
+		{
+			name:     "missing environment name",
+			format:   SeedFormatYAML,
+			data:     "environments:\n  - environment:\n      _id: env-dev\n      _organizationId: org\n      identifier: dev\n",
+			expected: "invalid seed: environments[0].environment.name: required",
+		},
+		{
+			name:     "missing environment _organizationId",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[{"environment":{"_id":"env-dev","name":"dev","identifier":"dev"}}]}`,
+			expected: "invalid seed: environments[0].environment._organizationId: required",
+		},
+		{
+			name:     "missing nested API key field",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[{"environment":{"_id":"env-dev","name":"dev","_organizationId":"org","identifier":"dev","apiKeys":[{"key":"k"}]}}]}`,
+			expected: "invalid seed: environments[0].environment.apiKeys[0]._userId: required",
+		},
+		{
+			name:     "missing subscriber field",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[` + seedEnvironment("dev", `"subscribers":[`+seedSubscriber("alice")+`,{"subscriberId":"bob"}]`) + `]}`,
+			expected: "invalid seed: environments[0].subscribers[1]._organizationId: required",
+		},
+		{
+			name:     "duplicate environment identifier",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[` + seedEnvironment("dev", "") + `,` + seedEnvironment("dev", "") + `]}`,
+			expected: `invalid seed: environments[1].environment.identifier: duplicate value "dev", also used by environments[0].environment.identifier`,
+		},
+		{
+			name:     "duplicate subscriber identifier",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[` + seedEnvironment("dev", `"subscribers":[`+seedSubscriber("alice")+`,`+seedSubscriber("alice")+`]`) + `]}`,
+			expected: `invalid seed: environments[0].subscribers[1].subscriberId: duplicate value "alice", also used by environments[0].subscribers[0].subscriberId`,
+		},
+		{
+			name:     "duplicate YAML key",
+			format:   SeedFormatYAML,
+			data:     "environments:\n  - environment:\n      _id: env-dev\n      _id: env-prod\n",
+			expected: "error decoding seed YAML: yaml: unmarshal errors:\n  line 4: mapping key \"_id\" already defined at line 3",
+		},
+		{
+			name:     "unknown entity field",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[` + seedEnvironment("dev", `"tenants":[{"identifier":"acme","color":"red"}]`) + `]}`,
+			expected: `invalid seed: environments[0].tenants[0]: unknown fields: [color]`,
+		},
+		{
+			name:     "unknown DTO field",
+			format:   SeedFormatYAML,
+			data:     "environments:\n  - environment:\n      _id: env-dev\n      name: dev\n      _organizationId: org\n      identifier: dev\n      region: eu\n",
+			expected: `invalid seed: environments[0].environment: unknown fields: [region]`,
+		},
+		{
+			name:     "unknown resource",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[` + seedEnvironment("dev", `"feeds":[]`) + `]}`,
+			expected: `error decoding seed: json: unknown field "feeds"`,
+		},
+		{
+			name:     "unknown subscriber preferences",
+			format:   SeedFormatYAML,
+			data:     "environments:\n  - environment:\n      _id: env-dev\n      name: dev\n      _organizationId: org\n      identifier: dev\n    preferences:\n      - subscriberId: bob\n        preferences:\n          global:\n            enabled: true\n            channels: {}\n          workflows: []\n",
+			expected: `invalid seed: environments[0].preferences[0].subscriberId: unknown subscriber "bob"`,
+		},
+		{
+			name:     "unknown outcome integration",
+			format:   SeedFormatJSON,
+			data:     `{"environments":[` + seedEnvironment("dev", `"outcomes":[{"integrationId":"int-email","outcome":"delay","delayMs":100}]`) + `]}`,
+			expected: `invalid seed: environments[0].outcomes[0].integrationId: unknown integration "int-email"`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			seed, err := ParseSeed([]byte(test.data), test.format)
+
+			if test.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if len(seed.Environments) != 1 {
+					t.Errorf("expected 1 environment, got: %d", len(seed.Environments))
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", test.expected)
+			}
+
+			if !strings.HasPrefix(err.Error(), test.expected) {
+				t.Errorf("expected error %q, got: %s", test.expected, err)
+			}
+		})
+	}
+}
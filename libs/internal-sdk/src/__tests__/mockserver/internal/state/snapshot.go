@@ -0,0 +1,61 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// SnapshotVersion is the current Snapshot format version. It must be
+	// incremented whenever the shape of the exported state changes, so
+	// outdated fixtures fail to restore instead of silently losing data.
+	SnapshotVersion = 5
+)
+
+// Snapshot is a versioned export of the complete Store contents.
+type Snapshot struct {
+	// Format version, which must match SnapshotVersion on restore.
+	Version int `json:"version"`
+
+	// Environments and their resources.
+	Environments []EnvironmentState `json:"environments"`
+}
+
+// rawSnapshot is the undecoded form of Snapshot.
+type rawSnapshot struct {
+	Version      *int                  `json:"version"`
+	Environments []rawEnvironmentState `json:"environments"`
+}
+
+// ParseSnapshot decodes and validates a JSON snapshot previously exported via
+// [Store.Snapshot]. Snapshots of any other version are rejected.
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	var raw rawSnapshot
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&raw)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding snapshot: %w", err)
+	}
+
+	if raw.Version == nil {
+		return nil, fmt.Errorf("invalid snapshot: missing version, expected %d", SnapshotVersion)
+	}
+
+	if *raw.Version != SnapshotVersion {
+		return nil, fmt.Errorf("invalid snapshot: unsupported version %d, expected %d", *raw.Version, SnapshotVersion)
+	}
+
+	environments, err := parseEnvironmentStates(raw.Environments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	return &Snapshot{
+		Version:      SnapshotVersion,
+		Environments: environments,
+	}, nil
+}
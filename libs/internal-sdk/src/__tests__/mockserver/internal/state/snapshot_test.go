@@ -0,0 +1,62 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseSnapshot(t *testing.T) {
+	environment := `{"environment":{"_id":"env-dev","name":"dev","_organizationId":"org","identifier":"dev"}}`
+
+	for _, test := range []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name: "current version",
+			data: fmt.Sprintf(`{"version":%d,"environments":[%s]}`, SnapshotVersion, environment),
+		},
+		{
+			name:     "missing version",
+			data:     `{"environments":[` + environment + `]}`,
+			expected: fmt.Sprintf("invalid snapshot: missing version, expected %d", SnapshotVersion),
+		},
+		{
+			name:     "old version",
+			data:     fmt.Sprintf(`{"version":%d,"environments":[%s]}`, SnapshotVersion-1, environment),
+			expected: fmt.Sprintf("invalid snapshot: unsupported version %d, expected %d", SnapshotVersion-1, SnapshotVersion),
+		},
+		{
+			name:     "unknown field",
+			data:     fmt.Sprintf(`{"version":%d,"environments":[],"exportedAt":"2025-01-01T00:00:00Z"}`, SnapshotVersion),
+			expected: `error decoding snapshot: json: unknown field "exportedAt"`,
+		},
+		{
+			name:     "unknown entity field",
+			data:     fmt.Sprintf(`{"version":%d,"environments":[{"environment":{"_id":"env-dev","name":"dev","_organizationId":"org","identifier":"dev","region":"eu"}}]}`, SnapshotVersion),
+			expected: "invalid snapshot: environments[0].environment: unknown fields: [region]",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot, err := ParseSnapshot([]byte(test.data))
+
+			if test.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if snapshot.Version != SnapshotVersion || len(snapshot.Environments) != 1 {
+					t.Errorf("expected current version with 1 environment, got: %+v", snapshot)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.HasPrefix(err.Error(), test.expected) {
+				t.Errorf("expected error %q, got: %v", test.expected, err)
+			}
+		})
+	}
+}
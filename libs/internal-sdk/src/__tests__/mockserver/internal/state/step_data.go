@@ -0,0 +1,307 @@
+package state
+
+import (
+	"fmt"
+	"reflect"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/sdk/utils"
+)
+
+// stepControl describes a control of a step type, for its data and UI
+// schemas.
+type stepControl struct {
+	// Control values property name.
+	name string
+
+	// Editor component of the property.
+	component components.UIComponentEnum
+
+	// JSON schema of the property.
+	schema map[string]any
+
+	// Whether the property is required.
+	required bool
+}
+
+// timeUnits are the units of delay and digest step amounts.
+var timeUnits = []any{"seconds", "minutes", "hours", "days", "weeks", "months"}
+
+// skipControl is the control of all step types for conditionally skipping the
+// step.
+var skipControl = stepControl{name: "skip", component: components.UIComponentEnumQueryEditor, schema: map[string]any{"type": "object"}}
+
+// stepControls are the controls of each step type, in the UI group of the
+// step type.
+var stepControls = map[components.WorkflowResponseDtoStepType]struct {
+	group    components.UISchemaGroupEnum
+	controls []stepControl
+}{
+	components.WorkflowResponseDtoStepTypeInApp: {
+		group: components.UISchemaGroupEnumInApp,
+		controls: []stepControl{
+			skipControl,
+			{name: "subject", component: components.UIComponentEnumInAppPrimarySubject, schema: map[string]any{"type": "string"}},
+			{name: "body", component: components.UIComponentEnumInAppBody, schema: map[string]any{"type": "string"}, required: true},
+			{name: "avatar", component: components.UIComponentEnumInAppAvatar, schema: map[string]any{"type": "string", "format": "uri"}},
+			{name: "primaryAction", component: components.UIComponentEnumInAppButtonDropdown, schema: map[string]any{"type": "object"}},
+			{name: "secondaryAction", component: components.UIComponentEnumInAppButtonDropdown, schema: map[string]any{"type": "object"}},
+			{name: "redirect", component: components.UIComponentEnumURLTextBox, schema: map[string]any{"type": "object"}},
+			{name: "disableOutputSanitization", component: components.UIComponentEnumInAppDisableSanitizationSwitch, schema: map[string]any{"type": "boolean", "default": false}},
+			{name: "data", component: components.UIComponentEnumData, schema: map[string]any{"type": "object"}},
+		},
+	},
+	components.WorkflowResponseDtoStepTypeEmail: {
+		group: components.UISchemaGroupEnumEmail,
+		controls: []stepControl{
+			skipControl,
+			{name: "subject", component: components.UIComponentEnumTextFullLine, schema: map[string]any{"type": "string"}, required: true},
+			{name: "body", component: components.UIComponentEnumEmailBody, schema: map[string]any{"type": "string", "default": ""}},
+			{name: "editorType", component: components.UIComponentEnumEmailEditorSelect, schema: map[string]any{"type": "string", "enum": []any{"block", "html"}, "default": "block"}},
+			{name: "layoutId", component: components.UIComponentEnumLayoutSelect, schema: map[string]any{"type": []any{"string", "null"}}},
+			{name: "disableOutputSanitization", component: components.UIComponentEnumDisableSanitizationSwitch, schema: map[string]any{"type": "boolean", "default": false}},
+		},
+	},
+	components.WorkflowResponseDtoStepTypeSms: {
+		group: components.UISchemaGroupEnumSms,
+		controls: []stepControl{
+			skipControl,
+			{name: "body", component: components.UIComponentEnumSmsBody, schema: map[string]any{"type": "string"}, required: true},
+		},
+	},
+	components.WorkflowResponseDtoStepTypeChat: {
+		group: components.UISchemaGroupEnumChat,
+		controls: []stepControl{
+			skipControl,
+			{name: "body", component: components.UIComponentEnumChatBody, schema: map[string]any{"type": "string"}, required: true},
+		},
+	},
+	components.WorkflowResponseDtoStepTypePush: {
+		group: components.UISchemaGroupEnumPush,
+		controls: []stepControl{
+			skipControl,
+			{name: "subject", component: components.UIComponentEnumPushSubject, schema: map[string]any{"type": "string"}, required: true},
+			{name: "body", component: components.UIComponentEnumPushBody, schema: map[string]any{"type": "string"}, required: true},
+		},
+	},
+	components.WorkflowResponseDtoStepTypeDelay: {
+		group: components.UISchemaGroupEnumDelay,
+		controls: []stepControl{
+			skipControl,
+			{name: "type", component: components.UIComponentEnumDelayType, schema: map[string]any{"type": "string", "enum": []any{"regular"}, "default": "regular"}},
+			{name: "amount", component: components.UIComponentEnumDelayAmount, schema: map[string]any{"type": "number", "minimum": 1}, required: true},
+			{name: "unit", component: components.UIComponentEnumDelayUnit, schema: map[string]any{"type": "string", "enum": timeUnits}, required: true},
+		},
+	},
+	components.WorkflowResponseDtoStepTypeDigest: {
+		group: components.UISchemaGroupEnumDigest,
+		controls: []stepControl{
+			skipControl,
+			{name: "amount", component: components.UIComponentEnumDigestAmount, schema: map[string]any{"type": "number", "minimum": 1}},
+			{name: "unit", component: components.UIComponentEnumDigestUnit, schema: map[string]any{"type": "string", "enum": timeUnits}},
+			{name: "digestKey", component: components.UIComponentEnumDigestKey, schema: map[string]any{"type": "string"}},
+			{name: "cron", component: components.UIComponentEnumDigestCron, schema: map[string]any{"type": "string"}},
+		},
+	},
+	components.WorkflowResponseDtoStepTypeCustom: {
+		controls: []stepControl{
+			{name: "custom", component: components.UIComponentEnumData, schema: map[string]any{"type": "object"}},
+		},
+	},
+}
+
+// stepOutputs are the JSON schema properties of the outputs of each step type,
+// which are available to later steps as steps.{stepId}.
+var stepOutputs = map[components.WorkflowResponseDtoStepType]map[string]any{
+	components.WorkflowResponseDtoStepTypeInApp: {
+		"seen":         map[string]any{"type": "boolean"},
+		"read":         map[string]any{"type": "boolean"},
+		"lastSeenDate": map[string]any{"type": []any{"string", "null"}, "format": "date-time"},
+		"lastReadDate": map[string]any{"type": []any{"string", "null"}, "format": "date-time"},
+	},
+	components.WorkflowResponseDtoStepTypeDigest: {
+		"eventCount": map[string]any{"type": "number"},
+		"events": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"id":      map[string]any{"type": "string"},
+					"time":    map[string]any{"type": "string", "format": "date-time"},
+					"payload": map[string]any{"type": "object"},
+				},
+			},
+		},
+	},
+}
+
+// subscriberVariables is the JSON schema of the subscriber variables available
+// to all steps.
+var subscriberVariables = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"subscriberId": map[string]any{"type": "string"},
+		"firstName":    map[string]any{"type": "string"},
+		"lastName":     map[string]any{"type": "string"},
+		"email":        map[string]any{"type": "string", "format": "email"},
+		"phone":        map[string]any{"type": "string"},
+		"avatar":       map[string]any{"type": "string", "format": "uri"},
+		"locale":       map[string]any{"type": "string"},
+		"timezone":     map[string]any{"type": "string"},
+		"data":         map[string]any{"type": "object", "additionalProperties": true},
+	},
+	"required":             []any{"subscriberId"},
+	"additionalProperties": false,
+}
+
+// StepData returns the step with the step identifier or database identifier of
+// the workflow with the workflow identifier or database identifier, as edited
+// by the dashboard. Its controls contain the control values and the data and
+// UI schemas of the step type, unless the stored step defines them, and its
+// variables are the subscriber, payload and previous step outputs available
+// to the step.
+func StepData(environment *EnvironmentState, workflowID string, stepID string) (*components.WorkflowResponseDtoStep, error) {
+	index := environment.WorkflowIndex(workflowID)
+
+	if index < 0 {
+		return nil, fmt.Errorf("workflow %s: %w", workflowID, ErrNotFound)
+	}
+
+	workflow := &environment.Workflows[index]
+
+	for i := range workflow.Steps {
+		if stepString(&workflow.Steps[i], "StepID") != stepID && stepString(&workflow.Steps[i], "ID") != stepID {
+			continue
+		}
+
+		step, err := clone(workflow.Steps[i])
+		if err != nil {
+			return nil, err
+		}
+
+		err = setStepControls(&step)
+		if err != nil {
+			return nil, err
+		}
+
+		stepVariant(&step).FieldByName("Variables").Set(reflect.ValueOf(stepVariables(workflow, i)))
+
+		return &step, nil
+	}
+
+	return nil, fmt.Errorf("step %s of workflow %s: %w", stepID, workflowID, ErrNotFound)
+}
+
+// setStepControls sets the control values, data schema and UI schema of the
+// controls of step.
+func setStepControls(step *components.WorkflowResponseDtoStep) error {
+	variant := stepVariant(step)
+
+	if !variant.IsValid() {
+		return nil
+	}
+
+	controls := variant.FieldByName("Controls")
+
+	if controlValues := variant.FieldByName("ControlValues"); !controlValues.IsNil() {
+		data, err := utils.MarshalJSON(controlValues.Interface(), "", true)
+		if err != nil {
+			return fmt.Errorf("error encoding control values of step %s: %w", stepString(step, "StepID"), err)
+		}
+
+		err = utils.UnmarshalJSON(data, controls.FieldByName("Values").Addr().Interface(), "", true, false)
+		if err != nil {
+			return fmt.Errorf("error decoding control values of step %s: %w", stepString(step, "StepID"), err)
+		}
+	}
+
+	definition, ok := stepControls[step.Type]
+
+	if !ok {
+		return nil
+	}
+
+	if dataSchema := controls.FieldByName("DataSchema"); dataSchema.Len() == 0 {
+		dataSchema.Set(reflect.ValueOf(controlsDataSchema(definition.controls)))
+	}
+
+	if uiSchema := controls.FieldByName("UISchema"); uiSchema.IsNil() {
+		result := &components.UISchema{
+			Properties: make(map[string]components.UISchemaProperty, len(definition.controls)),
+		}
+
+		if definition.group != "" {
+			result.Group = definition.group.ToPointer()
+		}
+
+		for _, control := range definition.controls {
+			result.Properties[control.name] = components.UISchemaProperty{Component: control.component}
+		}
+
+		uiSchema.Set(reflect.ValueOf(result))
+	}
+
+	return nil
+}
+
+// controlsDataSchema returns the JSON schema of the control values.
+func controlsDataSchema(controls []stepControl) map[string]any {
+	properties := make(map[string]any, len(controls))
+	required := []any{}
+
+	for _, control := range controls {
+		properties[control.name] = control.schema
+
+		if control.required {
+			required = append(required, control.name)
+		}
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+// stepVariables returns the JSON schema of the variables available to the
+// step at index of the workflow: the subscriber, the payload of the workflow
+// payload schema and the outputs of all previous steps.
+func stepVariables(workflow *components.WorkflowResponseDto, index int) map[string]any {
+	payload := map[string]any{"type": "object", "additionalProperties": true}
+
+	if len(workflow.PayloadSchema) > 0 {
+		payload = workflow.PayloadSchema
+	}
+
+	steps := make(map[string]any, index)
+
+	for i := range index {
+		outputs := stepOutputs[workflow.Steps[i].Type]
+
+		if outputs == nil {
+			outputs = map[string]any{}
+		}
+
+		steps[stepString(&workflow.Steps[i], "StepID")] = map[string]any{
+			"type":                 "object",
+			"properties":           outputs,
+			"additionalProperties": false,
+		}
+	}
+
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"subscriber": subscriberVariables,
+			"payload":    payload,
+			"steps": map[string]any{
+				"type":                 "object",
+				"properties":           steps,
+				"additionalProperties": false,
+			},
+		},
+		"additionalProperties": false,
+	}
+}
@@ -0,0 +1,74 @@
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestStepData(t *testing.T) {
+	dev, _ := testEnvironments(t)
+
+	step, err := StepData(dev, "welcome", "email")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	email := step.EmailStepResponseDto
+
+	if email == nil {
+		t.Fatalf("expected email step, got: %+v", step)
+	}
+
+	if email.Controls.Values.Subject != "Welcome" || email.Controls.Values.LayoutID == nil || *email.Controls.Values.LayoutID != "default-layout" {
+		t.Errorf("expected control values of the stored step, got: %+v", email.Controls.Values)
+	}
+
+	if email.Controls.UISchema == nil || *email.Controls.UISchema.Group != components.UISchemaGroupEnumEmail || email.Controls.UISchema.Properties["body"].Component != components.UIComponentEnumEmailBody {
+		t.Errorf("expected email UI schema, got: %+v", email.Controls.UISchema)
+	}
+
+	properties, _ := email.Controls.DataSchema["properties"].(map[string]any)
+
+	if _, ok := properties["layoutId"]; !ok {
+		t.Errorf("expected layoutId in data schema, got: %+v", email.Controls.DataSchema)
+	}
+
+	if required, _ := email.Controls.DataSchema["required"].([]any); len(required) != 1 || required[0] != "subject" {
+		t.Errorf("expected subject to be required, got: %+v", email.Controls.DataSchema["required"])
+	}
+
+	// The in-app step precedes the email step, so its outputs are available.
+	variables, _ := email.Variables["properties"].(map[string]any)
+	steps, _ := variables["steps"].(map[string]any)
+	stepProperties, _ := steps["properties"].(map[string]any)
+	inbox, _ := stepProperties["inbox"].(map[string]any)
+	outputs, _ := inbox["properties"].(map[string]any)
+
+	if _, ok := outputs["seen"]; !ok || len(stepProperties) != 1 {
+		t.Errorf("expected outputs of the inbox step, got: %+v", stepProperties)
+	}
+
+	if _, ok := variables["subscriber"]; !ok {
+		t.Errorf("expected subscriber variables, got: %+v", variables)
+	}
+
+	// The stored workflow is unchanged.
+	if dev.Workflows[0].Steps[1].EmailStepResponseDto.Controls.UISchema != nil {
+		t.Errorf("expected stored step to be unchanged")
+	}
+
+	step, err = StepData(dev, "wf-dev-welcome", "step-dev-inbox")
+
+	if err != nil || step.InAppStepResponseDto == nil || *step.InAppStepResponseDto.Controls.Values.Body != "Welcome {{subscriber.firstName}}" {
+		t.Errorf("expected in-app step by database identifiers, got: %+v, %v", step, err)
+	}
+
+	for _, ids := range [][2]string{{"unknown", "email"}, {"welcome", "unknown"}} {
+		if _, err := StepData(dev, ids[0], ids[1]); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%v: expected not found error, got: %v", ids, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package state
+
+import (
+	"reflect"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// stepVariant returns the addressable value of the set variant of step, such
+// as its EmailStepResponseDto, or an invalid value if no variant is set. All
+// variants share the fields of StepResponseDto, such as StepID and Issues.
+func stepVariant(step *components.WorkflowResponseDtoStep) reflect.Value {
+	value := reflect.ValueOf(step).Elem()
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+
+		if field.Kind() == reflect.Pointer && !field.IsNil() && field.Elem().Kind() == reflect.Struct {
+			return field.Elem()
+		}
+	}
+
+	return reflect.Value{}
+}
+
+// stepString returns the string field of the set variant of step, or an
+// empty string if no variant is set.
+func stepString(step *components.WorkflowResponseDtoStep, field string) string {
+	variant := stepVariant(step)
+
+	if !variant.IsValid() {
+		return ""
+	}
+
+	return variant.FieldByName(field).String()
+}
+
+// setStepString sets the string field of the set variant of step, if any.
+func setStepString(step *components.WorkflowResponseDtoStep, field string, value string) {
+	variant := stepVariant(step)
+
+	if !variant.IsValid() {
+		return
+	}
+
+	variant.FieldByName(field).SetString(value)
+}
+
+// stepIssues returns the issues field of the set variant of step, or nil if no
+// variant is set.
+func stepIssues(step *components.WorkflowResponseDtoStep) **components.StepIssuesDto {
+	variant := stepVariant(step)
+
+	if !variant.IsValid() {
+		return nil
+	}
+
+	return variant.FieldByName("Issues").Addr().Interface().(**components.StepIssuesDto)
+}
+
+// stepChannel returns the integration channel delivering the step, or false
+// for steps without delivery, such as delay and digest steps.
+func stepChannel(step *components.WorkflowResponseDtoStep) (components.IntegrationResponseDtoChannel, bool) {
+	switch step.Type {
+	case components.WorkflowResponseDtoStepTypeInApp:
+		return components.IntegrationResponseDtoChannelInApp, true
+	case components.WorkflowResponseDtoStepTypeEmail:
+		return components.IntegrationResponseDtoChannelEmail, true
+	case components.WorkflowResponseDtoStepTypeSms:
+		return components.IntegrationResponseDtoChannelSms, true
+	case components.WorkflowResponseDtoStepTypeChat:
+		return components.IntegrationResponseDtoChannelChat, true
+	case components.WorkflowResponseDtoStepTypePush:
+		return components.IntegrationResponseDtoChannelPush, true
+	default:
+		return "", false
+	}
+}
+
+// stepLayoutID returns the layout identifier referenced by an email step, or
+// an empty string if the step is not an email step or references no layout.
+func stepLayoutID(step *components.WorkflowResponseDtoStep) string {
+	if step.EmailStepResponseDto == nil || step.EmailStepResponseDto.ControlValues == nil || step.EmailStepResponseDto.ControlValues.LayoutID == nil {
+		return ""
+	}
+
+	return *step.EmailStepResponseDto.ControlValues.LayoutID
+}
@@ -0,0 +1,103 @@
+package state
+
+import (
+	"sync"
+)
+
+// Store is the in-memory state of the server. It is safe for concurrent use.
+type Store struct {
+	// Environments in insertion order.
+	environments []EnvironmentState
+
+	// Mutex to protect environments.
+	environmentsMutex *sync.RWMutex
+}
+
+// New creates an empty Store.
+func New() *Store {
+	return &Store{
+		environmentsMutex: new(sync.RWMutex),
+	}
+}
+
+// Environments returns all environments and their resources.
+func (s *Store) Environments() []EnvironmentState {
+	s.environmentsMutex.RLock()
+	defer s.environmentsMutex.RUnlock()
+
+	result := make([]EnvironmentState, len(s.environments))
+	copy(result, s.environments)
+
+	return result
+}
+
+// Load adds all environments of the given seed to the Store. Environments with
+// an identifier that is already stored are replaced.
+func (s *Store) Load(seed *Seed) {
+	s.environmentsMutex.Lock()
+	defer s.environmentsMutex.Unlock()
+
+	for _, environment := range seed.Environments {
+		replaced := false
+
+		for i, existing := range s.environments {
+			if existing.Environment.Identifier == environment.Environment.Identifier {
+				s.environments[i] = environment
+				replaced = true
+
+				break
+			}
+		}
+
+		if !replaced {
+			s.environments = append(s.environments, environment)
+		}
+	}
+}
+
+// Update calls fn with a copy of all environments and their resources, which
+// replaces the stored environments once fn returns without error. Updates are
+// serialized, and a failing fn leaves the Store unchanged.
+func (s *Store) Update(fn func(environments []EnvironmentState) error) error {
+	s.environmentsMutex.Lock()
+	defer s.environmentsMutex.Unlock()
+
+	environments, err := clone(s.environments)
+	if err != nil {
+		return err
+	}
+
+	err = fn(environments)
+	if err != nil {
+		return err
+	}
+
+	s.environments = environments
+
+	return nil
+}
+
+// Reset removes all contents of the Store.
+func (s *Store) Reset() {
+	s.environmentsMutex.Lock()
+	defer s.environmentsMutex.Unlock()
+
+	s.environments = nil
+}
+
+// Restore replaces all contents of the Store with the given snapshot.
+func (s *Store) Restore(snapshot *Snapshot) {
+	s.environmentsMutex.Lock()
+	defer s.environmentsMutex.Unlock()
+
+	s.environments = make([]EnvironmentState, len(snapshot.Environments))
+	copy(s.environments, snapshot.Environments)
+}
+
+// Snapshot exports all contents of the Store at the current SnapshotVersion.
+func (s *Store) Snapshot() *Snapshot {
+	return &Snapshot{
+		Version:      SnapshotVersion,
+		Environments: s.Environments(),
+	}
+}
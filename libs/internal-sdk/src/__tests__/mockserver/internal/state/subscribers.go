@@ -0,0 +1,468 @@
+package state
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+	"mockserver/internal/sdk/utils"
+)
+
+// SubscriberListOptions filters, sorts and pages ListSubscribers results.
+type SubscriberListOptions struct {
+	// Internal identifier of the subscriber after which results start.
+	After string
+
+	// Internal identifier of the subscriber before which results end.
+	Before string
+
+	// Case-insensitive substring of the email of returned subscribers.
+	Email string
+
+	// Whether the After or Before subscriber is included in the results.
+	IncludeCursor bool
+
+	// Maximum number of subscribers returned.
+	Limit int
+
+	// Case-insensitive substring of the full name of returned subscribers.
+	Name string
+
+	// Sort field, either createdAt (the default) or updatedAt.
+	OrderBy string
+
+	// Sort direction, by default descending.
+	OrderDirection string
+
+	// Substring of the phone number of returned subscribers.
+	Phone string
+
+	// Substring of the subscriber identifier of returned subscribers.
+	SubscriberID string
+}
+
+// SubscriberIndex returns the index of the subscriber with the subscriber
+// identifier, or -1 if there is none.
+func (e *EnvironmentState) SubscriberIndex(subscriberID string) int {
+	for i, subscriber := range e.Subscribers {
+		if subscriber.SubscriberID == subscriberID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// UpsertSubscriber creates the subscriber with the subscriber identifier of
+// dto, or updates the fields set in dto of the existing subscriber. It returns
+// the stored subscriber and whether it was created.
+func UpsertSubscriber(environment *EnvironmentState, dto components.CreateSubscriberRequestDto, now time.Time) (*components.SubscriberResponseDto, bool, error) {
+	if dto.SubscriberID == "" {
+		return nil, false, fmt.Errorf("subscriberId should not be empty: %w", ErrInvalid)
+	}
+
+	index := environment.SubscriberIndex(dto.SubscriberID)
+	created := index < 0
+
+	if created {
+		id := NewID()
+
+		environment.Subscribers = append(environment.Subscribers, components.SubscriberResponseDto{
+			ID:             &id,
+			SubscriberID:   dto.SubscriberID,
+			OrganizationID: environment.Environment.OrganizationID,
+			EnvironmentID:  environment.Environment.ID,
+			CreatedAt:      FormatTime(now),
+		})
+
+		index = len(environment.Subscribers) - 1
+	}
+
+	subscriber := &environment.Subscribers[index]
+
+	for _, field := range []struct {
+		value  *string
+		target **string
+	}{
+		{value: dto.FirstName, target: &subscriber.FirstName},
+		{value: dto.LastName, target: &subscriber.LastName},
+		{value: dto.Email, target: &subscriber.Email},
+		{value: dto.Phone, target: &subscriber.Phone},
+		{value: dto.Avatar, target: &subscriber.Avatar},
+		{value: dto.Locale, target: &subscriber.Locale},
+		{value: dto.Timezone, target: &subscriber.Timezone},
+	} {
+		if field.value != nil {
+			value := *field.value
+			*field.target = &value
+		}
+	}
+
+	if dto.Data != nil {
+		subscriber.Data = dto.Data
+	}
+
+	subscriber.UpdatedAt = FormatTime(now)
+
+	return subscriber, created, nil
+}
+
+// BulkUpsertSubscribers decodes each raw CreateSubscriberRequestDto and
+// creates or updates its subscriber like UpsertSubscriber. Subscribers which
+// cannot be decoded or are invalid are reported as failed without affecting
+// the others.
+func BulkUpsertSubscribers(environment *EnvironmentState, raws []json.RawMessage, now time.Time) components.BulkCreateSubscriberResponseDto {
+	result := components.BulkCreateSubscriberResponseDto{
+		Created: []components.CreatedSubscriberDto{},
+		Failed:  []components.FailedOperationDto{},
+		Updated: []components.UpdatedSubscriberDto{},
+	}
+
+	for _, raw := range raws {
+		var dto components.CreateSubscriberRequestDto
+
+		err := utils.UnmarshalJSON(raw, &dto, "", true, false)
+		if err == nil {
+			err = validateSubscriber(dto)
+		}
+
+		if err == nil {
+			var created bool
+
+			_, created, err = UpsertSubscriber(environment, dto, now)
+			if err == nil && created {
+				result.Created = append(result.Created, components.CreatedSubscriberDto{SubscriberID: dto.SubscriberID})
+
+				continue
+			}
+
+			if err == nil {
+				result.Updated = append(result.Updated, components.UpdatedSubscriberDto{SubscriberID: dto.SubscriberID})
+
+				continue
+			}
+		}
+
+		message := err.Error()
+		failed := components.FailedOperationDto{Message: &message}
+
+		if dto.SubscriberID != "" {
+			failed.SubscriberID = &dto.SubscriberID
+		}
+
+		result.Failed = append(result.Failed, failed)
+	}
+
+	return result
+}
+
+// GetSubscriber returns the subscriber with the subscriber identifier.
+func GetSubscriber(environment *EnvironmentState, subscriberID string) (*components.SubscriberResponseDto, error) {
+	index := environment.SubscriberIndex(subscriberID)
+
+	if index < 0 || environment.Subscribers[index].Deleted {
+		return nil, fmt.Errorf("subscriber %s: %w", subscriberID, ErrNotFound)
+	}
+
+	return &environment.Subscribers[index], nil
+}
+
+// ListSubscribers returns the page of subscribers which are not deleted and
+// match the options, with the cursors of the previous and next pages. Cursors
+// are internal subscriber identifiers and are nil without further pages.
+func ListSubscribers(environment *EnvironmentState, options SubscriberListOptions) ([]components.SubscriberResponseDto, *string, *string, error) {
+	result := make([]components.SubscriberResponseDto, 0, len(environment.Subscribers))
+
+	for _, subscriber := range environment.Subscribers {
+		name := strings.TrimSpace(stringValue(subscriber.FirstName) + " " + stringValue(subscriber.LastName))
+
+		if subscriber.Deleted ||
+			!containsFold(stringValue(subscriber.Email), options.Email) ||
+			!containsFold(name, options.Name) ||
+			!strings.Contains(stringValue(subscriber.Phone), options.Phone) ||
+			!strings.Contains(subscriber.SubscriberID, options.SubscriberID) {
+			continue
+		}
+
+		result = append(result, subscriber)
+	}
+
+	slices.SortStableFunc(result, func(a, b components.SubscriberResponseDto) int {
+		var order int
+
+		switch options.OrderBy {
+		case "updatedAt":
+			order = cmp.Compare(a.UpdatedAt, b.UpdatedAt)
+		default:
+			order = cmp.Compare(a.CreatedAt, b.CreatedAt)
+		}
+
+		if order == 0 {
+			order = cmp.Compare(stringValue(a.ID), stringValue(b.ID))
+		}
+
+		if options.OrderDirection != "ASC" {
+			order = -order
+		}
+
+		return order
+	})
+
+	start, end := 0, len(result)
+
+	for _, cursor := range []string{options.After, options.Before} {
+		if cursor == "" {
+			continue
+		}
+
+		index := slices.IndexFunc(result, func(subscriber components.SubscriberResponseDto) bool {
+			return stringValue(subscriber.ID) == cursor
+		})
+
+		if index < 0 {
+			return nil, nil, nil, fmt.Errorf("cursor %s does not match a subscriber: %w", cursor, ErrInvalid)
+		}
+
+		switch {
+		case cursor == options.After && options.IncludeCursor:
+			start = index
+		case cursor == options.After:
+			start = index + 1
+		case options.IncludeCursor:
+			end = index + 1
+		default:
+			end = index
+		}
+	}
+
+	if options.Limit > 0 && end-start > options.Limit {
+		if options.Before != "" && options.After == "" {
+			start = end - options.Limit
+		} else {
+			end = start + options.Limit
+		}
+	}
+
+	if start > end {
+		start = end
+	}
+
+	var previous, next *string
+
+	if start > 0 && start < len(result) {
+		previous = result[start].ID
+	}
+
+	if end < len(result) && end > 0 {
+		next = result[end-1].ID
+	}
+
+	return result[start:end], previous, next, nil
+}
+
+// UpdateSubscriberOnlineFlag sets whether the subscriber with the subscriber
+// identifier is online, recording now as the time it was last online.
+func UpdateSubscriberOnlineFlag(environment *EnvironmentState, subscriberID string, isOnline bool, now time.Time) (*components.SubscriberResponseDto, error) {
+	subscriber, err := GetSubscriber(environment, subscriberID)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := FormatTime(now)
+
+	subscriber.IsOnline = &isOnline
+	subscriber.LastOnlineAt = &timestamp
+	subscriber.UpdatedAt = timestamp
+
+	return subscriber, nil
+}
+
+// UpdateSubscriberChannel stores the credentials of dto for its provider and
+// integration on the subscriber with the subscriber identifier. Without an
+// integration identifier, the primary or first active integration of the
+// provider is used. The stored credentials are replaced, or with merge only
+// the set fields are replaced and device tokens are appended. Device tokens
+// are deduplicated.
+func UpdateSubscriberChannel(environment *EnvironmentState, subscriberID string, dto components.UpdateSubscriberChannelRequestDto, merge bool, now time.Time) (*components.SubscriberResponseDto, error) {
+	index := environment.SubscriberIndex(subscriberID)
+
+	if index < 0 {
+		return nil, fmt.Errorf("subscriber %s: %w", subscriberID, ErrNotFound)
+	}
+
+	integration := environment.channelIntegration(string(dto.ProviderID), dto.IntegrationIdentifier)
+
+	if integration == nil {
+		return nil, fmt.Errorf("no active %s integration: %w", dto.ProviderID, ErrNotFound)
+	}
+
+	subscriber := &environment.Subscribers[index]
+	integrationID := ""
+
+	if integration.ID != nil {
+		integrationID = *integration.ID
+	}
+
+	identifier := integration.Identifier
+	channelIndex := slices.IndexFunc(subscriber.Channels, func(channel components.ChannelSettingsDto) bool {
+		return channel.ProviderID == dto.ProviderID && channel.IntegrationID == integrationID
+	})
+
+	if channelIndex < 0 {
+		subscriber.Channels = append(subscriber.Channels, components.ChannelSettingsDto{
+			ProviderID:            dto.ProviderID,
+			IntegrationIdentifier: &identifier,
+			IntegrationID:         integrationID,
+		})
+
+		channelIndex = len(subscriber.Channels) - 1
+	}
+
+	channel := &subscriber.Channels[channelIndex]
+
+	if merge {
+		channel.Credentials = mergeCredentials(channel.Credentials, dto.Credentials)
+	} else {
+		channel.Credentials = dto.Credentials
+	}
+
+	channel.Credentials.DeviceTokens = uniqueStrings(channel.Credentials.DeviceTokens)
+	subscriber.UpdatedAt = FormatTime(now)
+
+	return subscriber, nil
+}
+
+// DeleteSubscriberCredentials removes the credentials of all integrations of
+// the provider from the subscriber with the subscriber identifier.
+func DeleteSubscriberCredentials(environment *EnvironmentState, subscriberID string, providerID string, now time.Time) error {
+	index := environment.SubscriberIndex(subscriberID)
+
+	if index < 0 {
+		return fmt.Errorf("subscriber %s: %w", subscriberID, ErrNotFound)
+	}
+
+	subscriber := &environment.Subscribers[index]
+	channels := slices.DeleteFunc(subscriber.Channels, func(channel components.ChannelSettingsDto) bool {
+		return string(channel.ProviderID) == providerID
+	})
+
+	if len(channels) == len(subscriber.Channels) {
+		return fmt.Errorf("subscriber %s has no %s credentials: %w", subscriberID, providerID, ErrNotFound)
+	}
+
+	subscriber.Channels = channels
+	subscriber.UpdatedAt = FormatTime(now)
+
+	return nil
+}
+
+// channelIntegration returns the active integration of the provider with the
+// identifier, or without an identifier the primary or first active
+// integration of the provider, or nil if there is none.
+func (e *EnvironmentState) channelIntegration(providerID string, identifier *string) *components.IntegrationResponseDto {
+	var result *components.IntegrationResponseDto
+
+	for i := range e.Integrations {
+		integration := &e.Integrations[i]
+
+		if integration.ProviderID != providerID || !integration.Active || integration.Deleted {
+			continue
+		}
+
+		if identifier != nil {
+			if integration.Identifier == *identifier {
+				return integration
+			}
+
+			continue
+		}
+
+		if result == nil || (integration.Primary && !result.Primary) {
+			result = integration
+		}
+	}
+
+	return result
+}
+
+// mergeCredentials returns credentials with the fields set in update replaced
+// and the device tokens of update appended.
+func mergeCredentials(credentials components.ChannelCredentials, update components.ChannelCredentials) components.ChannelCredentials {
+	for _, field := range []struct {
+		value  *string
+		target **string
+	}{
+		{value: update.WebhookURL, target: &credentials.WebhookURL},
+		{value: update.Channel, target: &credentials.Channel},
+		{value: update.AlertUID, target: &credentials.AlertUID},
+		{value: update.Title, target: &credentials.Title},
+		{value: update.ImageURL, target: &credentials.ImageURL},
+		{value: update.State, target: &credentials.State},
+		{value: update.ExternalURL, target: &credentials.ExternalURL},
+	} {
+		if field.value != nil {
+			*field.target = field.value
+		}
+	}
+
+	credentials.DeviceTokens = append(slices.Clone(credentials.DeviceTokens), update.DeviceTokens...)
+
+	return credentials
+}
+
+// validateSubscriber verifies the subscriber identifier of dto is set and its
+// email, if any, is a valid address.
+func validateSubscriber(dto components.CreateSubscriberRequestDto) error {
+	if dto.SubscriberID == "" {
+		return fmt.Errorf("subscriberId should not be empty: %w", ErrInvalid)
+	}
+
+	if dto.Email != nil {
+		_, err := mail.ParseAddress(*dto.Email)
+		if err != nil {
+			return fmt.Errorf("email must be an email: %w", ErrInvalid)
+		}
+	}
+
+	return nil
+}
+
+// containsFold reports whether substr is within s, ignoring case.
+func containsFold(s string, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// stringValue returns the value of the string pointer, or an empty string if
+// it is nil.
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
+
+// uniqueStrings returns values without duplicates, in order of their first
+// occurrence.
+func uniqueStrings(values []string) []string {
+	var result []string
+
+	for _, value := range values {
+		if !slices.Contains(result, value) {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
+// subscriberPayload returns the subscriber fields of a trigger recipient or
+// actor.
+func subscriberPayload(payload components.SubscriberPayloadDto) (components.CreateSubscriberRequestDto, error) {
+	return convert[components.CreateSubscriberRequestDto](payload)
+}
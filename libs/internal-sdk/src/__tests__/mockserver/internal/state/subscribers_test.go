@@ -0,0 +1,182 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestUpdateSubscriberChannel(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	title := "Alice"
+
+	result, err := UpdateSubscriberChannel(dev, "alice", components.UpdateSubscriberChannelRequestDto{
+		ProviderID:  components.ChatOrPushProviderEnumFcm,
+		Credentials: components.ChannelCredentials{DeviceTokens: []string{"a", "b", "a"}, Title: &title},
+	}, false, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(result.Channels) != 1 || result.Channels[0].IntegrationID != "int-dev-push" || *result.Channels[0].IntegrationIdentifier != "fcm" {
+		t.Fatalf("expected fcm channel, got: %+v", result.Channels)
+	}
+
+	if !slices.Equal(result.Channels[0].Credentials.DeviceTokens, []string{"a", "b"}) {
+		t.Errorf("expected deduplicated device tokens, got: %v", result.Channels[0].Credentials.DeviceTokens)
+	}
+
+	result, err = UpdateSubscriberChannel(dev, "alice", components.UpdateSubscriberChannelRequestDto{
+		ProviderID:  components.ChatOrPushProviderEnumFcm,
+		Credentials: components.ChannelCredentials{DeviceTokens: []string{"b", "c"}},
+	}, true, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	credentials := result.Channels[0].Credentials
+
+	if len(result.Channels) != 1 || !slices.Equal(credentials.DeviceTokens, []string{"a", "b", "c"}) || credentials.Title == nil {
+		t.Errorf("expected appended device tokens and kept title, got: %+v", credentials)
+	}
+
+	result, err = UpdateSubscriberChannel(dev, "alice", components.UpdateSubscriberChannelRequestDto{
+		ProviderID:  components.ChatOrPushProviderEnumFcm,
+		Credentials: components.ChannelCredentials{DeviceTokens: []string{"d"}},
+	}, false, now)
+
+	if err != nil || !slices.Equal(result.Channels[0].Credentials.DeviceTokens, []string{"d"}) || result.Channels[0].Credentials.Title != nil {
+		t.Errorf("expected replaced credentials, got: %+v, %v", result, err)
+	}
+
+	for _, test := range []struct {
+		name         string
+		subscriberID string
+		dto          components.UpdateSubscriberChannelRequestDto
+	}{
+		{name: "unknown subscriber", subscriberID: "unknown", dto: components.UpdateSubscriberChannelRequestDto{ProviderID: components.ChatOrPushProviderEnumFcm}},
+		{name: "missing integration", subscriberID: "alice", dto: components.UpdateSubscriberChannelRequestDto{ProviderID: components.ChatOrPushProviderEnumSlack}},
+	} {
+		if _, err := UpdateSubscriberChannel(dev, test.subscriberID, test.dto, false, now); !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected not found error, got: %v", test.name, err)
+		}
+	}
+
+	err = DeleteSubscriberCredentials(dev, "alice", "fcm", now)
+
+	if err != nil || len(dev.Subscribers[0].Channels) != 0 {
+		t.Errorf("expected credentials to be deleted, got: %+v, %v", dev.Subscribers[0].Channels, err)
+	}
+
+	if err := DeleteSubscriberCredentials(dev, "alice", "fcm", now); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestListSubscribers(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for i, subscriberID := range []string{"bob", "carol", "dave"} {
+		email := subscriberID + "@example.com"
+
+		_, _, err := UpsertSubscriber(dev, components.CreateSubscriberRequestDto{SubscriberID: subscriberID, Email: &email}, now.Add(time.Duration(i+1)*time.Minute))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	subscriberIDs := func(subscribers []components.SubscriberResponseDto) []string {
+		var result []string
+
+		for _, subscriber := range subscribers {
+			result = append(result, subscriber.SubscriberID)
+		}
+
+		return result
+	}
+
+	result, previous, next, err := ListSubscribers(dev, SubscriberListOptions{Limit: 2})
+
+	if err != nil || !slices.Equal(subscriberIDs(result), []string{"dave", "carol"}) || previous != nil || next == nil {
+		t.Fatalf("expected first page of newest subscribers, got: %v, %v, %v, %v", subscriberIDs(result), previous, next, err)
+	}
+
+	result, previous, next, err = ListSubscribers(dev, SubscriberListOptions{Limit: 2, After: *next})
+
+	if err != nil || !slices.Equal(subscriberIDs(result), []string{"bob", "alice"}) || previous == nil || next != nil {
+		t.Fatalf("expected last page, got: %v, %v, %v, %v", subscriberIDs(result), previous, next, err)
+	}
+
+	result, _, _, err = ListSubscribers(dev, SubscriberListOptions{Limit: 2, Before: *previous})
+
+	if err != nil || !slices.Equal(subscriberIDs(result), []string{"dave", "carol"}) {
+		t.Errorf("expected previous page, got: %v, %v", subscriberIDs(result), err)
+	}
+
+	result, _, _, err = ListSubscribers(dev, SubscriberListOptions{Email: "CAROL@", OrderDirection: "ASC"})
+
+	if err != nil || !slices.Equal(subscriberIDs(result), []string{"carol"}) {
+		t.Errorf("expected email match, got: %v, %v", subscriberIDs(result), err)
+	}
+
+	if _, _, _, err := ListSubscribers(dev, SubscriberListOptions{After: "unknown"}); !errors.Is(err, ErrInvalid) {
+		t.Errorf("expected invalid error, got: %v", err)
+	}
+}
+
+func TestUpdateSubscriberOnlineFlag(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result, err := UpdateSubscriberOnlineFlag(dev, "alice", true, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.IsOnline == nil || !*result.IsOnline || result.LastOnlineAt == nil || *result.LastOnlineAt != "2025-01-02T03:04:05.000Z" {
+		t.Errorf("expected online subscriber, got: %+v", result)
+	}
+
+	if _, err := UpdateSubscriberOnlineFlag(dev, "unknown", true, now); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestBulkUpsertSubscribers(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result := BulkUpsertSubscribers(dev, []json.RawMessage{
+		json.RawMessage(`{"subscriberId":"alice","firstName":"Alice"}`),
+		json.RawMessage(`{"subscriberId":"bob"}`),
+		json.RawMessage(`{"subscriberId":"carol","email":"not an email"}`),
+		json.RawMessage(`{"firstName":"Nobody"}`),
+		json.RawMessage(`{"subscriberId":1}`),
+	}, now)
+
+	if len(result.Updated) != 1 || result.Updated[0].SubscriberID != "alice" {
+		t.Errorf("expected alice to be updated, got: %+v", result.Updated)
+	}
+
+	if len(result.Created) != 1 || result.Created[0].SubscriberID != "bob" {
+		t.Errorf("expected bob to be created, got: %+v", result.Created)
+	}
+
+	if len(result.Failed) != 3 || result.Failed[0].SubscriberID == nil || *result.Failed[0].SubscriberID != "carol" || result.Failed[1].SubscriberID != nil {
+		t.Errorf("expected three failures, got: %+v", result.Failed)
+	}
+
+	if len(dev.Subscribers) != 2 || *dev.Subscribers[0].FirstName != "Alice" {
+		t.Errorf("expected only valid subscribers to be stored, got: %+v", dev.Subscribers)
+	}
+}
@@ -0,0 +1,96 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// Tenant is a tenant of an environment, which triggers can reference as their
+// tenant context. The API has no tenant response shape, so tenants use the
+// fields of the trigger tenant payload.
+type Tenant struct {
+	// Unique identifier of the tenant within its environment.
+	Identifier string `json:"identifier"`
+
+	// Name of the tenant.
+	Name *string `json:"name,omitempty"`
+
+	// Custom data of the tenant, such as branding.
+	Data map[string]any `json:"data,omitempty"`
+
+	// Time the tenant was created.
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// Time the tenant was last updated.
+	UpdatedAt string `json:"updatedAt,omitempty"`
+}
+
+// TenantIndex returns the index of the tenant with the identifier, or -1 if
+// there is none.
+func (e *EnvironmentState) TenantIndex(identifier string) int {
+	for i, tenant := range e.Tenants {
+		if tenant.Identifier == identifier {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// UpsertTenant creates the tenant with the identifier of dto, or updates the
+// name of the existing tenant if set in dto. Tenant data of dto is not stored,
+// as the generated TenantPayloadDtoData declares no properties and drops it
+// while decoding.
+func UpsertTenant(environment *EnvironmentState, dto components.TenantPayloadDto, now time.Time) (*Tenant, error) {
+	if dto.Identifier == nil || *dto.Identifier == "" {
+		return nil, fmt.Errorf("tenant identifier should not be empty: %w", ErrInvalid)
+	}
+
+	index := environment.TenantIndex(*dto.Identifier)
+
+	if index < 0 {
+		environment.Tenants = append(environment.Tenants, Tenant{
+			Identifier: *dto.Identifier,
+			CreatedAt:  FormatTime(now),
+		})
+
+		index = len(environment.Tenants) - 1
+	}
+
+	tenant := &environment.Tenants[index]
+
+	if dto.Name != nil {
+		name := *dto.Name
+		tenant.Name = &name
+	}
+
+	tenant.UpdatedAt = FormatTime(now)
+
+	return tenant, nil
+}
+
+// triggerTenant resolves the tenant context of a trigger. Tenant payloads are
+// created or updated, while tenant identifiers must reference a stored tenant.
+// It returns nil without a tenant context.
+func triggerTenant(environment *EnvironmentState, tenant *components.TriggerEventRequestDtoTenant, now time.Time) (*Tenant, error) {
+	if tenant == nil {
+		return nil, nil
+	}
+
+	switch tenant.Type {
+	case components.TriggerEventRequestDtoTenantTypeStr:
+		index := environment.TenantIndex(*tenant.Str)
+
+		if index < 0 {
+			return nil, fmt.Errorf("no_tenant_found: tenant %s does not exist: %w", *tenant.Str, ErrInvalid)
+		}
+
+		return &environment.Tenants[index], nil
+	case components.TriggerEventRequestDtoTenantTypeTenantPayloadDto:
+		return UpsertTenant(environment, *tenant.TenantPayloadDto, now)
+	default:
+		return nil, nil
+	}
+}
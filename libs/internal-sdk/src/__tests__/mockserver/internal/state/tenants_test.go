@@ -0,0 +1,49 @@
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+func TestTriggerEventTenant(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	identifier := "globex"
+	name := "Globex"
+
+	if len(dev.Tenants) != 1 || dev.Tenants[0].Data["brandColor"] != "#ff0000" {
+		t.Fatalf("expected seeded acme tenant, got: %+v", dev.Tenants)
+	}
+
+	for _, tenant := range []components.TriggerEventRequestDtoTenant{
+		components.CreateTriggerEventRequestDtoTenantStr("acme"),
+		components.CreateTriggerEventRequestDtoTenantTenantPayloadDto(components.TenantPayloadDto{Identifier: &identifier, Name: &name}),
+	} {
+		_, err := TriggerEvent(dev, components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice"), Tenant: &tenant}, now)
+
+		if err != nil {
+			t.Errorf("unexpected error: %s", err)
+		}
+	}
+
+	if len(dev.Tenants) != 2 || dev.Tenants[1].Identifier != identifier || *dev.Tenants[1].Name != name || dev.Tenants[1].CreatedAt != "2025-01-02T03:04:05.000Z" {
+		t.Errorf("expected globex tenant to be created, got: %+v", dev.Tenants)
+	}
+
+	for _, test := range []struct {
+		name   string
+		tenant components.TriggerEventRequestDtoTenant
+	}{
+		{name: "unknown identifier", tenant: components.CreateTriggerEventRequestDtoTenantStr("unknown")},
+		{name: "payload without identifier", tenant: components.CreateTriggerEventRequestDtoTenantTenantPayloadDto(components.TenantPayloadDto{Name: &name})},
+	} {
+		_, err := TriggerEvent(dev, components.TriggerEventRequestDto{WorkflowID: "welcome", To: components.CreateToUnion2Str("alice"), Tenant: &test.tenant}, now)
+
+		if !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: expected invalid error, got: %v", test.name, err)
+		}
+	}
+}
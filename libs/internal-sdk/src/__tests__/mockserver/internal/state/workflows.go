@@ -0,0 +1,236 @@
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// SyncWorkflow promotes the workflow with the workflow identifier or database
+// identifier from source into target, including its steps, control values,
+// preferences and the layouts referenced by its email steps. The workflow is
+// matched on its workflow identifier: an existing target workflow is updated
+// in place, keeping its database identifiers, otherwise it is created.
+//
+// Steps delivered through a channel without an active integration in target
+// are given a MISSING_INTEGRATION issue and the workflow an ERROR status.
+func SyncWorkflow(source *EnvironmentState, target *EnvironmentState, id string, now time.Time) (*components.WorkflowResponseDto, error) {
+	index := source.WorkflowIndex(id)
+
+	if index < 0 {
+		return nil, fmt.Errorf("workflow %s: %w", id, ErrNotFound)
+	}
+
+	workflow, err := clone(source.Workflows[index])
+	if err != nil {
+		return nil, err
+	}
+
+	var existing *components.WorkflowResponseDto
+
+	if targetIndex := target.WorkflowIndex(workflow.WorkflowID); targetIndex >= 0 {
+		existing = &target.Workflows[targetIndex]
+	}
+
+	workflow.ID = NewID()
+	workflow.CreatedAt = FormatTime(now)
+	workflow.UpdatedAt = FormatTime(now)
+
+	if existing != nil {
+		workflow.ID = existing.ID
+		workflow.CreatedAt = existing.CreatedAt
+	}
+
+	for i := range workflow.Steps {
+		step := &workflow.Steps[i]
+
+		setStepString(step, "ID", syncedStepID(existing, stepString(step, "StepID")))
+		setStepString(step, "WorkflowDatabaseID", workflow.ID)
+
+		if layoutID := stepLayoutID(step); layoutID != "" {
+			targetLayoutID, err := syncLayout(source, target, layoutID, now)
+			if err != nil {
+				return nil, fmt.Errorf("workflow %s step %s: %w", workflow.WorkflowID, stepString(step, "StepID"), err)
+			}
+
+			*step.EmailStepResponseDto.ControlValues.LayoutID = targetLayoutID
+		}
+
+		setMissingIntegrationIssue(step, target)
+
+		if issues := stepIssues(step); issues != nil && *issues != nil && len((*issues).Integration) > 0 {
+			workflow.Status = components.WorkflowStatusEnumError
+		}
+	}
+
+	if existing != nil {
+		*existing = workflow
+	} else {
+		target.Workflows = append(target.Workflows, workflow)
+	}
+
+	return &workflow, nil
+}
+
+// DuplicateWorkflow deep-copies the workflow with the workflow identifier or
+// database identifier, including its steps, control values and preferences.
+// The copy gets new database identifiers and a workflow identifier derived
+// from name, by default the original name with a (copy) suffix. Tags and the
+// description are carried over unless given. Names and workflow identifiers
+// already used by another workflow are refused.
+func DuplicateWorkflow(environment *EnvironmentState, id string, dto components.DuplicateWorkflowDto, now time.Time) (*components.WorkflowResponseDto, error) {
+	index := environment.WorkflowIndex(id)
+
+	if index < 0 {
+		return nil, fmt.Errorf("workflow %s: %w", id, ErrNotFound)
+	}
+
+	workflow, err := clone(environment.Workflows[index])
+	if err != nil {
+		return nil, err
+	}
+
+	workflow.Name += " (copy)"
+
+	if dto.Name != nil {
+		workflow.Name = *dto.Name
+	}
+
+	workflow.WorkflowID = Slugify(workflow.Name)
+
+	if workflow.WorkflowID == "" {
+		return nil, fmt.Errorf("workflow name %q has no letters or digits: %w", workflow.Name, ErrInvalid)
+	}
+
+	for _, existing := range environment.Workflows {
+		if existing.Name == workflow.Name {
+			return nil, fmt.Errorf("workflow with name %q already exists: %w", workflow.Name, ErrConflict)
+		}
+
+		if existing.WorkflowID == workflow.WorkflowID {
+			return nil, fmt.Errorf("workflow with workflowId %q already exists: %w", workflow.WorkflowID, ErrConflict)
+		}
+	}
+
+	if dto.Tags != nil {
+		workflow.Tags = dto.Tags
+	}
+
+	if dto.Description != nil {
+		workflow.Description = dto.Description
+	}
+
+	workflow.ID = NewID()
+	workflow.Slug = workflow.WorkflowID + "_wf_" + workflow.ID
+	workflow.Origin = components.ResourceOriginEnumNovuCloud
+	workflow.CreatedAt = FormatTime(now)
+	workflow.UpdatedAt = FormatTime(now)
+	workflow.LastTriggeredAt = nil
+
+	for i := range workflow.Steps {
+		step := &workflow.Steps[i]
+		stepID := NewID()
+
+		setStepString(step, "ID", stepID)
+		setStepString(step, "Slug", Slugify(stepString(step, "Name"))+"_st_"+stepID)
+		setStepString(step, "WorkflowID", workflow.WorkflowID)
+		setStepString(step, "WorkflowDatabaseID", workflow.ID)
+		setStepString(step, "Origin", string(components.ResourceOriginEnumNovuCloud))
+	}
+
+	environment.Workflows = append(environment.Workflows, workflow)
+
+	return &workflow, nil
+}
+
+// syncedStepID returns the database identifier of the step with the step
+// identifier in the existing target workflow, or a new one.
+func syncedStepID(existing *components.WorkflowResponseDto, stepID string) string {
+	if existing == nil {
+		return NewID()
+	}
+
+	for i := range existing.Steps {
+		if stepString(&existing.Steps[i], "StepID") == stepID {
+			return stepString(&existing.Steps[i], "ID")
+		}
+	}
+
+	return NewID()
+}
+
+// syncLayout copies the layout with the layout identifier or database
+// identifier from source into target, matched on layout identifier, and
+// returns its layout identifier. An existing target layout keeps its database
+// identifier and default flag, and a created layout is only the default if
+// target has no default layout.
+func syncLayout(source *EnvironmentState, target *EnvironmentState, id string, now time.Time) (string, error) {
+	index := source.LayoutIndex(id)
+
+	if index < 0 {
+		return "", fmt.Errorf("layout %s: %w", id, ErrNotFound)
+	}
+
+	layout, err := clone(source.Layouts[index])
+	if err != nil {
+		return "", err
+	}
+
+	layout.UpdatedAt = FormatTime(now)
+
+	for i, existing := range target.Layouts {
+		if existing.LayoutID == layout.LayoutID {
+			layout.ID = existing.ID
+			layout.CreatedAt = existing.CreatedAt
+			layout.IsDefault = existing.IsDefault
+			target.Layouts[i] = layout
+
+			return layout.LayoutID, nil
+		}
+	}
+
+	layout.ID = NewID()
+	layout.CreatedAt = FormatTime(now)
+	layout.IsDefault = layout.IsDefault && target.DefaultLayoutIndex() < 0
+	target.Layouts = append(target.Layouts, layout)
+
+	return layout.LayoutID, nil
+}
+
+// setMissingIntegrationIssue replaces the integration issues of the step with
+// a MISSING_INTEGRATION issue if the environment has no active integration for
+// the channel of the step.
+func setMissingIntegrationIssue(step *components.WorkflowResponseDtoStep, environment *EnvironmentState) {
+	channel, ok := stepChannel(step)
+	issues := stepIssues(step)
+
+	if !ok || issues == nil {
+		return
+	}
+
+	if *issues != nil {
+		(*issues).Integration = nil
+	}
+
+	if environment.HasActiveIntegration(channel) {
+		if *issues != nil && len((*issues).Controls) == 0 {
+			*issues = nil
+		}
+
+		return
+	}
+
+	if *issues == nil {
+		*issues = &components.StepIssuesDto{}
+	}
+
+	(*issues).Integration = map[string][]components.StepIntegrationIssue{
+		string(channel): {
+			{
+				IssueType: components.StepIntegrationIssueEnumMissingIntegration,
+				Message:   fmt.Sprintf("Missing active %s integration in environment %s", channel, environment.Environment.Name),
+			},
+		},
+	}
+}
@@ -0,0 +1,178 @@
+package state
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"mockserver/internal/sdk/models/components"
+)
+
+// testEnvironments returns development and production environments of the
+// testdata seed.
+func testEnvironments(t *testing.T) (*EnvironmentState, *EnvironmentState) {
+	t.Helper()
+
+	seed, err := ReadSeedFile("../server/testdata/seed.yaml")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return &seed.Environments[0], &seed.Environments[1]
+}
+
+func TestSyncWorkflow(t *testing.T) {
+	dev, prod := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	result, err := SyncWorkflow(dev, prod, "welcome", now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if result.CreatedAt != "2025-01-02T03:04:05.000Z" || result.UpdatedAt != result.CreatedAt {
+		t.Errorf("expected timestamps of now, got: %s, %s", result.CreatedAt, result.UpdatedAt)
+	}
+
+	if len(result.Tags) != 1 || result.Preferences.Default.Channels["email"].Enabled == nil || *result.Preferences.Default.Channels["email"].Enabled {
+		t.Errorf("expected tags and preferences to be copied, got: %v, %+v", result.Tags, result.Preferences)
+	}
+
+	for i := range result.Steps {
+		step := &result.Steps[i]
+
+		if stepString(step, "WorkflowDatabaseID") != result.ID || stepString(step, "ID") == stepString(&dev.Workflows[0].Steps[i], "ID") {
+			t.Errorf("step %d: expected new identifiers, got: %+v", i, stepVariant(step).Interface())
+		}
+	}
+
+	// The in-app integration exists in production, the email one does not.
+	if issues := result.Steps[0].InAppStepResponseDto.Issues; issues != nil {
+		t.Errorf("expected no in_app issues, got: %+v", issues)
+	}
+
+	issues := result.Steps[1].EmailStepResponseDto.Issues
+
+	if issues == nil || len(issues.Integration["email"]) != 1 || issues.Integration["email"][0].IssueType != components.StepIntegrationIssueEnumMissingIntegration {
+		t.Errorf("expected MISSING_INTEGRATION email issue, got: %+v", issues)
+	}
+
+	if result.Status != components.WorkflowStatusEnumError {
+		t.Errorf("expected ERROR status, got: %s", result.Status)
+	}
+
+	if len(prod.Layouts) != 1 || prod.Layouts[0].LayoutID != "default-layout" || prod.Layouts[0].ID == dev.Layouts[0].ID || !prod.Layouts[0].IsDefault {
+		t.Errorf("expected referenced layout to be created as default, got: %+v", prod.Layouts)
+	}
+
+	// The copy is independent of the source workflow.
+	result.Steps[1].EmailStepResponseDto.ControlValues.Subject = "changed"
+
+	if dev.Workflows[0].Steps[1].EmailStepResponseDto.ControlValues.Subject != "Welcome" {
+		t.Errorf("expected source workflow to be unchanged")
+	}
+
+	// Syncing again with an email integration updates in place and clears
+	// the issue.
+	prod.Integrations = append(prod.Integrations, dev.Integrations[0])
+	stepIDs := []string{stepString(&prod.Workflows[0].Steps[0], "ID"), stepString(&prod.Workflows[0].Steps[1], "ID")}
+
+	result, err = SyncWorkflow(dev, prod, "wf-dev-welcome", now.Add(time.Hour))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(prod.Workflows) != 1 || prod.Workflows[0].ID != result.ID || result.CreatedAt != "2025-01-02T03:04:05.000Z" || result.UpdatedAt != "2025-01-02T04:04:05.000Z" {
+		t.Errorf("expected workflow to be updated in place, got: %+v", prod.Workflows)
+	}
+
+	for i, stepID := range stepIDs {
+		if got := stepString(&result.Steps[i], "ID"); got != stepID {
+			t.Errorf("step %d: expected identifier %s to be kept, got: %s", i, stepID, got)
+		}
+	}
+
+	if result.Steps[1].EmailStepResponseDto.Issues != nil || result.Status != components.WorkflowStatusEnumActive {
+		t.Errorf("expected issue to be cleared, got: %+v %s", result.Steps[1].EmailStepResponseDto.Issues, result.Status)
+	}
+
+	_, err = SyncWorkflow(dev, prod, "unknown", now)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
+
+func TestDuplicateWorkflow(t *testing.T) {
+	dev, _ := testEnvironments(t)
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	name := "Welcome Tenant A"
+
+	result, err := DuplicateWorkflow(dev, "welcome", components.DuplicateWorkflowDto{Name: &name}, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	original := &dev.Workflows[0]
+
+	if result.WorkflowID != "welcome-tenant-a" || result.ID == original.ID || result.Slug != "welcome-tenant-a_wf_"+result.ID {
+		t.Errorf("expected new identifiers derived from the name, got: %s, %s, %s", result.WorkflowID, result.ID, result.Slug)
+	}
+
+	if len(result.Tags) != 1 || result.Tags[0] != "onboarding" || *result.Preferences.Default.Channels["email"].Enabled {
+		t.Errorf("expected tags and preferences to be carried over, got: %v, %+v", result.Tags, result.Preferences)
+	}
+
+	for i := range result.Steps {
+		step := &result.Steps[i]
+
+		if stepString(step, "StepID") != stepString(&original.Steps[i], "StepID") || stepString(step, "ID") == stepString(&original.Steps[i], "ID") {
+			t.Errorf("step %d: expected same step identifier with new database identifier", i)
+		}
+
+		if stepString(step, "WorkflowID") != result.WorkflowID || stepString(step, "WorkflowDatabaseID") != result.ID {
+			t.Errorf("step %d: expected workflow references of the copy, got: %+v", i, stepVariant(step).Interface())
+		}
+	}
+
+	// The copy is independent of the original.
+	dev.Workflows[len(dev.Workflows)-1].Steps[1].EmailStepResponseDto.ControlValues.Subject = "Tenant A"
+	dev.Workflows[len(dev.Workflows)-1].Preferences.Default.Channels["email"] = components.ChannelPreferenceDto{}
+
+	if original.Steps[1].EmailStepResponseDto.ControlValues.Subject != "Welcome" || original.Preferences.Default.Channels["email"].Enabled == nil {
+		t.Errorf("expected original workflow to be unchanged, got: %+v", original)
+	}
+
+	copied, err := DuplicateWorkflow(dev, "welcome", components.DuplicateWorkflowDto{Tags: []string{"copy"}}, now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if copied.Name != "Welcome (copy)" || copied.WorkflowID != "welcome-copy" || len(copied.Tags) != 1 || copied.Tags[0] != "copy" {
+		t.Errorf("unexpected default copy: %s, %s, %v", copied.Name, copied.WorkflowID, copied.Tags)
+	}
+
+	for _, test := range []struct {
+		name     string
+		expected error
+	}{
+		{name: "Welcome Tenant A", expected: ErrConflict},
+		{name: "welcome tenant-a", expected: ErrConflict},
+		{name: "!!!", expected: ErrInvalid},
+	} {
+		_, err := DuplicateWorkflow(dev, "welcome", components.DuplicateWorkflowDto{Name: &test.name}, now)
+
+		if !errors.Is(err, test.expected) {
+			t.Errorf("name %q: expected %v, got: %v", test.name, test.expected, err)
+		}
+	}
+
+	if _, err := DuplicateWorkflow(dev, "unknown", components.DuplicateWorkflowDto{}, now); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+// Package stub contains response stubs registered by tests, which override
+// the generated handlers for matching requests.
+package stub
@@ -0,0 +1,99 @@
+package stub
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathSegment is a single property name or array index of a JSON path.
+type jsonPathSegment struct {
+	index    int
+	isIndex  bool
+	property string
+}
+
+// parseJSONPath parses a JSON path of property names and array indexes, such
+// as $.to.subscriberId or $.actions[0].type.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSON path %q must start with $", path)
+	}
+
+	var result []jsonPathSegment
+
+	rest := path[1:]
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			property := rest[1 : end+1]
+
+			if property == "" {
+				return nil, fmt.Errorf("JSON path %q has an empty property name", path)
+			}
+
+			result = append(result, jsonPathSegment{property: property})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+
+			if end == -1 {
+				return nil, fmt.Errorf("JSON path %q has an unterminated index", path)
+			}
+
+			index, err := strconv.Atoi(rest[1:end])
+
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("JSON path %q has an invalid index %q", path, rest[1:end])
+			}
+
+			result = append(result, jsonPathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("JSON path %q is invalid at %q", path, rest)
+		}
+	}
+
+	return result, nil
+}
+
+// lookupJSONPath returns the value at the path of a decoded JSON document and
+// whether it exists.
+func lookupJSONPath(document any, path []jsonPathSegment) (any, bool) {
+	result := document
+
+	for _, segment := range path {
+		if segment.isIndex {
+			array, ok := result.([]any)
+
+			if !ok || segment.index >= len(array) {
+				return nil, false
+			}
+
+			result = array[segment.index]
+
+			continue
+		}
+
+		object, ok := result.(map[string]any)
+
+		if !ok {
+			return nil, false
+		}
+
+		result, ok = object[segment.property]
+
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return result, true
+}
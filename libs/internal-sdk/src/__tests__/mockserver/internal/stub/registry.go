@@ -0,0 +1,243 @@
+package stub
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+
+	"mockserver/internal/tracking"
+)
+
+// Registry holds registered stubs in matching order. It is safe for
+// concurrent use.
+type Registry struct {
+	counters map[string]int
+	mutex    sync.Mutex
+	nextID   int
+	stubs    []*Stub
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		counters: make(map[string]int),
+	}
+}
+
+// Add registers the stub, generating its ID if not set.
+func (r *Registry) Add(s Stub) (*Stub, error) {
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if s.ID == "" {
+		r.nextID++
+		s.ID = "stub_" + strconv.Itoa(r.nextID)
+	}
+
+	for _, existing := range r.stubs {
+		if existing.ID == s.ID {
+			return nil, fmt.Errorf("stub %s already exists", s.ID)
+		}
+	}
+
+	s.Calls = 0
+
+	// Newest first, then stable sort by descending priority.
+	r.stubs = append([]*Stub{&s}, r.stubs...)
+	slices.SortStableFunc(r.stubs, func(a *Stub, b *Stub) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+
+	result := s
+
+	return &result, nil
+}
+
+// Clear removes the stubs of the namespace, or all stubs and counters if
+// namespace is empty.
+func (r *Registry) Clear(namespace string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if namespace == "" {
+		r.counters = make(map[string]int)
+		r.stubs = nil
+
+		return
+	}
+
+	r.stubs = slices.DeleteFunc(r.stubs, func(s *Stub) bool {
+		return s.Namespace == namespace
+	})
+}
+
+// Empty returns whether no stubs are registered.
+func (r *Registry) Empty() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.stubs) == 0
+}
+
+// List returns the stubs of the namespace, or all of them if namespace is
+// empty, in matching order.
+func (r *Registry) List(namespace string) []*Stub {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	result := []*Stub{}
+
+	for _, s := range r.stubs {
+		if namespace == "" || s.Namespace == namespace {
+			stub := *s
+			result = append(result, &stub)
+		}
+	}
+
+	return result
+}
+
+// Remove removes the stub with the ID and returns whether it existed.
+func (r *Registry) Remove(id string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	count := len(r.stubs)
+
+	r.stubs = slices.DeleteFunc(r.stubs, func(s *Stub) bool {
+		return s.ID == id
+	})
+
+	return len(r.stubs) != count
+}
+
+// Responder returns a handler writing the response of the first stub matching
+// the request and its body, removing the stub once it responded the given
+// number of times. It returns nil if no stub matches.
+func (r *Registry) Responder(req *http.Request, body []byte) http.HandlerFunc {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var jsonBody any
+
+	// Non-JSON bodies only match stubs without body predicates.
+	_ = json.Unmarshal(body, &jsonBody)
+
+	namespace := req.Header.Get(tracking.TestInstanceIDHeader)
+
+	for i, s := range r.stubs {
+		pathParams, ok := s.match(req, namespace, jsonBody)
+
+		if !ok {
+			continue
+		}
+
+		s.Calls++
+
+		if s.Times > 0 && s.Calls >= s.Times {
+			r.stubs = slices.Delete(r.stubs, i, i+1)
+		}
+
+		data := &templateData{
+			Request: templateRequest{
+				Body:       jsonBody,
+				BodyText:   string(body),
+				Headers:    req.Header,
+				Method:     req.Method,
+				Namespace:  namespace,
+				Path:       req.URL.Path,
+				PathParams: pathParams,
+				Query:      req.URL.Query(),
+			},
+			Sequence: s.Calls,
+			counters: r.counters,
+		}
+
+		return func(w http.ResponseWriter, _ *http.Request) {
+			// Templates share the counters of the registry.
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+
+			err := s.write(w, data)
+
+			if err != nil {
+				http.Error(w, fmt.Sprintf("stub %s response error: %s", s.ID, err), http.StatusInternalServerError)
+			}
+		}
+	}
+
+	return nil
+}
+
+// write renders the stub response templates and writes the response.
+func (s *Stub) write(w http.ResponseWriter, data *templateData) error {
+	statusText, err := renderTemplate(s.compiled.status, data)
+
+	if err != nil {
+		return err
+	}
+
+	status, err := strconv.Atoi(statusText)
+
+	if err != nil || status < 100 || status > 999 {
+		return fmt.Errorf("invalid status %q", statusText)
+	}
+
+	headers := make(http.Header, len(s.compiled.headers))
+
+	for name, tmpl := range s.compiled.headers {
+		value, err := renderTemplate(tmpl, data)
+
+		if err != nil {
+			return err
+		}
+
+		headers.Set(name, value)
+	}
+
+	var body []byte
+
+	switch {
+	case s.compiled.hasJSONBody:
+		rendered, err := renderJSONTemplates(s.compiled.jsonBody, data)
+
+		if err != nil {
+			return err
+		}
+
+		body, err = json.Marshal(rendered)
+
+		if err != nil {
+			return err
+		}
+
+		if headers.Get("Content-Type") == "" {
+			headers.Set("Content-Type", "application/json")
+		}
+	case s.compiled.textBody != nil:
+		text, err := renderTemplate(s.compiled.textBody, data)
+
+		if err != nil {
+			return err
+		}
+
+		body = []byte(text)
+	}
+
+	for name, values := range headers {
+		w.Header()[name] = values
+	}
+
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+
+	return nil
+}
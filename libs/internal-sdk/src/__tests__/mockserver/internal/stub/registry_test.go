@@ -0,0 +1,214 @@
+package stub
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"mockserver/internal/tracking"
+)
+
+// respond returns the ID of the stub responding to the request, as set in the
+// response body by addStub, or an empty string if no stub matches.
+func respond(t *testing.T, r *Registry, req *http.Request, body string) string {
+	t.Helper()
+
+	responder := r.Responder(req, []byte(body))
+
+	if responder == nil {
+		return ""
+	}
+
+	recorder := httptest.NewRecorder()
+	responder(recorder, req)
+
+	return recorder.Body.String()
+}
+
+// addStub registers the stub with its ID as the text response body.
+func addStub(t *testing.T, r *Registry, s Stub) {
+	t.Helper()
+
+	s.Response.BodyText = s.ID
+
+	if _, err := r.Add(s); err != nil {
+		t.Fatalf("unexpected error adding stub %s: %s", s.ID, err)
+	}
+}
+
+func TestRegistryAddInvalid(t *testing.T) {
+	r := NewRegistry()
+
+	for _, test := range []struct {
+		stub     Stub
+		expected string
+	}{
+		{stub: Stub{Path: "/v1/environments"}, expected: "method is required"},
+		{stub: Stub{Method: http.MethodGet}, expected: `path "" must start with /`},
+		{stub: Stub{Method: http.MethodGet, Path: "v1/environments"}, expected: `path "v1/environments" must start with /`},
+		{stub: Stub{Method: http.MethodGet, Path: "/v1/{id"}, expected: "invalid path"},
+		{stub: Stub{Method: http.MethodGet, Path: "/", Times: -1}, expected: "times must not be negative"},
+		{stub: Stub{Method: http.MethodGet, Path: "/", Body: []BodyPredicate{{Path: "$.a"}}}, expected: "exactly one of equals, exists or matches is required"},
+		{stub: Stub{Method: http.MethodGet, Path: "/", Response: Response{Body: json.RawMessage(`{}`), BodyText: "text"}}, expected: "response.body cannot be combined with response.bodyText"},
+	} {
+		_, err := r.Add(test.stub)
+
+		if err == nil || !strings.Contains(err.Error(), test.expected) {
+			t.Errorf("expected error %q, got: %v", test.expected, err)
+		}
+	}
+
+	if !r.Empty() {
+		t.Errorf("expected no stubs after invalid additions, got: %d", len(r.List("")))
+	}
+}
+
+func TestRegistryOrder(t *testing.T) {
+	r := NewRegistry()
+
+	addStub(t, r, Stub{ID: "old", Method: http.MethodGet, Path: "/v1/environments"})
+	addStub(t, r, Stub{ID: "high", Priority: 1, Method: http.MethodGet, Path: "/v1/environments"})
+	addStub(t, r, Stub{ID: "new", Method: http.MethodGet, Path: "/v1/environments"})
+	addStub(t, r, Stub{ID: "low", Priority: -1, Method: http.MethodGet, Path: "/v1/environments"})
+
+	var ids []string
+
+	for _, s := range r.List("") {
+		ids = append(ids, s.ID)
+	}
+
+	if expected := []string{"high", "new", "old", "low"}; !slices.Equal(ids, expected) {
+		t.Errorf("expected matching order %v, got: %v", expected, ids)
+	}
+
+	for _, expected := range []string{"high", "new", "old", "low", ""} {
+		if got := respond(t, r, httptest.NewRequest(http.MethodGet, "/v1/environments", nil), ""); got != expected {
+			t.Errorf("expected stub %q to respond, got: %q", expected, got)
+		}
+
+		r.Remove(expected)
+	}
+}
+
+func TestRegistryTimes(t *testing.T) {
+	r := NewRegistry()
+
+	addStub(t, r, Stub{ID: "fallback", Method: http.MethodGet, Path: "/v1/environments"})
+	addStub(t, r, Stub{ID: "twice", Method: http.MethodGet, Path: "/v1/environments", Times: 2})
+
+	for _, expected := range []string{"twice", "twice", "fallback", "fallback"} {
+		if got := respond(t, r, httptest.NewRequest(http.MethodGet, "/v1/environments", nil), ""); got != expected {
+			t.Errorf("expected stub %q to respond, got: %q", expected, got)
+		}
+	}
+
+	stubs := r.List("")
+
+	if len(stubs) != 1 || stubs[0].ID != "fallback" || stubs[0].Calls != 2 {
+		t.Errorf("expected only the fallback stub with 2 calls, got: %+v", stubs)
+	}
+}
+
+func TestRegistryNamespace(t *testing.T) {
+	r := NewRegistry()
+
+	addStub(t, r, Stub{ID: "any", Method: http.MethodGet, Path: "/v1/environments"})
+	addStub(t, r, Stub{ID: "test-1", Namespace: "test-1", Method: http.MethodGet, Path: "/v1/environments"})
+	addStub(t, r, Stub{ID: "test-2", Namespace: "test-2", Method: http.MethodGet, Path: "/v1/environments"})
+
+	for namespace, expected := range map[string]string{"": "any", "test-1": "test-1", "test-2": "test-2", "test-3": "any"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/environments", nil)
+
+		if namespace != "" {
+			req.Header.Set(tracking.TestInstanceIDHeader, namespace)
+		}
+
+		if got := respond(t, r, req, ""); got != expected {
+			t.Errorf("%q: expected stub %q to respond, got: %q", namespace, expected, got)
+		}
+	}
+
+	if got := r.List("test-1"); len(got) != 1 || got[0].ID != "test-1" {
+		t.Errorf("expected only stub test-1 in namespace test-1, got: %+v", got)
+	}
+
+	r.Clear("test-1")
+
+	var ids []string
+
+	for _, s := range r.List("") {
+		ids = append(ids, s.ID)
+	}
+
+	if expected := []string{"test-2", "any"}; !slices.Equal(ids, expected) {
+		t.Errorf("expected stubs %v after clearing namespace test-1, got: %v", expected, ids)
+	}
+}
+
+func TestRegistryBodyPredicates(t *testing.T) {
+	exists := true
+	missing := false
+
+	for _, test := range []struct {
+		name      string
+		predicate BodyPredicate
+		matches   []string
+		misses    []string
+	}{
+		{
+			name:      "equals string",
+			predicate: BodyPredicate{Path: "$.to.subscriberId", Equals: json.RawMessage(`"alice"`)},
+			matches:   []string{`{"to":{"subscriberId":"alice","email":"a@example.com"}}`},
+			misses:    []string{`{"to":{"subscriberId":"bob"}}`, `{"to":"alice"}`, `{}`, `not json`},
+		},
+		{
+			name:      "equals object",
+			predicate: BodyPredicate{Path: "$.payload", Equals: json.RawMessage(`{"a":[1,2]}`)},
+			matches:   []string{`{"payload":{"a":[1,2]}}`},
+			misses:    []string{`{"payload":{"a":[1,2],"b":3}}`, `{"payload":{"a":[2,1]}}`},
+		},
+		{
+			name:      "array index",
+			predicate: BodyPredicate{Path: "$.actions[1].type", Equals: json.RawMessage(`"secondary"`)},
+			matches:   []string{`{"actions":[{"type":"primary"},{"type":"secondary"}]}`},
+			misses:    []string{`{"actions":[{"type":"secondary"}]}`, `{"actions":{"1":{"type":"secondary"}}}`},
+		},
+		{
+			name:      "exists",
+			predicate: BodyPredicate{Path: "$.overrides", Exists: &exists},
+			matches:   []string{`{"overrides":{}}`, `{"overrides":null}`},
+			misses:    []string{`{}`, `not json`},
+		},
+		{
+			name:      "not exists",
+			predicate: BodyPredicate{Path: "$.overrides", Exists: &missing},
+			matches:   []string{`{}`, `[]`},
+			misses:    []string{`{"overrides":{}}`},
+		},
+		{
+			name:      "matches",
+			predicate: BodyPredicate{Path: "$.name", Matches: "^wel"},
+			matches:   []string{`{"name":"welcome"}`},
+			misses:    []string{`{"name":"farewell"}`, `{"name":1}`, `{}`},
+		},
+	} {
+		r := NewRegistry()
+
+		addStub(t, r, Stub{ID: "stub", Method: http.MethodPost, Path: "/v1/events/trigger", Body: []BodyPredicate{test.predicate}})
+
+		for _, body := range test.matches {
+			if got := respond(t, r, httptest.NewRequest(http.MethodPost, "/v1/events/trigger", nil), body); got != "stub" {
+				t.Errorf("%s: expected %s to match, got: %q", test.name, body, got)
+			}
+		}
+
+		for _, body := range test.misses {
+			if got := respond(t, r, httptest.NewRequest(http.MethodPost, "/v1/events/trigger", nil), body); got != "" {
+				t.Errorf("%s: expected %s not to match, got: %q", test.name, body, got)
+			}
+		}
+	}
+}
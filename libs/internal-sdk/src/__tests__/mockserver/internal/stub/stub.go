@@ -0,0 +1,314 @@
+package stub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"mockserver/internal/handler/assert"
+
+	"github.com/gorilla/mux"
+)
+
+// Stub is a response returned instead of the generated handler response for
+// matching requests.
+type Stub struct {
+	// Unique identifier. Generated if not set on creation.
+	ID string `json:"id"`
+
+	// Test namespace, as sent via the tracking.TestInstanceIDHeader request
+	// header. Empty matches requests of any namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Stubs with a higher priority are matched first. Stubs with the same
+	// priority are matched newest first.
+	Priority int `json:"priority,omitempty"`
+
+	// HTTP method, such as POST.
+	Method string `json:"method"`
+
+	// URL path template, such as /v1/subscribers/{subscriberId}. Path
+	// parameter values are available to response templates.
+	Path string `json:"path"`
+
+	// Query parameters which must have exactly the given values.
+	Query map[string][]string `json:"query,omitempty"`
+
+	// Request headers which must have exactly the given values.
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Predicates the JSON request body must all satisfy.
+	Body []BodyPredicate `json:"body,omitempty"`
+
+	// Number of requests the stub responds to before it is removed. Zero
+	// responds to all requests until the stub is removed.
+	Times int `json:"times,omitempty"`
+
+	// Templated response.
+	Response Response `json:"response"`
+
+	// Number of requests the stub responded to, set when listing.
+	Calls int `json:"calls"`
+
+	// Parsed templates and JSON paths, set on creation.
+	compiled *compiledStub
+
+	// Route matching Method and Path.
+	route *mux.Route
+}
+
+// BodyPredicate is a condition on the value at a JSON path of the request
+// body. Exactly one of Equals, Exists or Matches must be set.
+type BodyPredicate struct {
+	// JSON path of property names and array indexes, such as
+	// $.to.subscriberId or $.actions[0].type.
+	Path string `json:"path"`
+
+	// JSON value the value must equal.
+	Equals json.RawMessage `json:"equals,omitempty"`
+
+	// Whether the value must exist or not.
+	Exists *bool `json:"exists,omitempty"`
+
+	// Regular expression the string value must match.
+	Matches string `json:"matches,omitempty"`
+}
+
+// Response is a templated response. String values of Status, Headers, Body
+// and BodyText are Go text/template templates, which can reference request
+// fields and sequence counters.
+type Response struct {
+	// Status code, either as a number or as a template. Defaults to 200.
+	Status StatusTemplate `json:"status,omitempty"`
+
+	// Response headers.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// JSON response body, in which every string is rendered as a template.
+	// Content-Type defaults to application/json.
+	Body json.RawMessage `json:"body,omitempty"`
+
+	// Text response body, rendered as a single template. Cannot be combined
+	// with Body.
+	BodyText string `json:"bodyText,omitempty"`
+}
+
+// StatusTemplate is a response status code, either as a number or as a
+// template rendering one.
+type StatusTemplate string
+
+// MarshalJSON encodes numeric status codes as JSON numbers.
+func (s StatusTemplate) MarshalJSON() ([]byte, error) {
+	if _, err := strconv.Atoi(string(s)); err == nil {
+		return []byte(s), nil
+	}
+
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON decodes a JSON number or string.
+func (s *StatusTemplate) UnmarshalJSON(data []byte) error {
+	var number int
+
+	if err := json.Unmarshal(data, &number); err == nil {
+		*s = StatusTemplate(strconv.Itoa(number))
+
+		return nil
+	}
+
+	var text string
+
+	if err := json.Unmarshal(data, &text); err != nil {
+		return errors.New("status must be a number or a template string")
+	}
+
+	*s = StatusTemplate(text)
+
+	return nil
+}
+
+// compiledStub holds the parsed predicates and templates of a Stub.
+type compiledStub struct {
+	bodyPaths    [][]jsonPathSegment
+	bodyPatterns []*regexp.Regexp
+	bodyValues   []any
+	hasJSONBody  bool
+	headers      map[string]*template.Template
+	jsonBody     any
+	status       *template.Template
+	textBody     *template.Template
+}
+
+// compile validates the stub and parses its predicates and templates.
+func (s *Stub) compile() error {
+	if s.Method == "" {
+		return errors.New("method is required")
+	}
+
+	if !strings.HasPrefix(s.Path, "/") {
+		return fmt.Errorf("path %q must start with /", s.Path)
+	}
+
+	if s.Times < 0 {
+		return errors.New("times must not be negative")
+	}
+
+	s.route = new(mux.Router).Path(s.Path).Methods(s.Method)
+
+	if err := s.route.GetError(); err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+
+	result := &compiledStub{
+		headers: make(map[string]*template.Template, len(s.Response.Headers)),
+	}
+
+	for i, predicate := range s.Body {
+		path, err := parseJSONPath(predicate.Path)
+
+		if err != nil {
+			return fmt.Errorf("body[%d]: %w", i, err)
+		}
+
+		var set int
+		var pattern *regexp.Regexp
+		var value any
+
+		if predicate.Equals != nil {
+			set++
+
+			if err := json.Unmarshal(predicate.Equals, &value); err != nil {
+				return fmt.Errorf("body[%d].equals: %w", i, err)
+			}
+		}
+
+		if predicate.Exists != nil {
+			set++
+		}
+
+		if predicate.Matches != "" {
+			set++
+
+			pattern, err = regexp.Compile(predicate.Matches)
+
+			if err != nil {
+				return fmt.Errorf("body[%d].matches: %w", i, err)
+			}
+		}
+
+		if set != 1 {
+			return fmt.Errorf("body[%d]: exactly one of equals, exists or matches is required", i)
+		}
+
+		result.bodyPaths = append(result.bodyPaths, path)
+		result.bodyPatterns = append(result.bodyPatterns, pattern)
+		result.bodyValues = append(result.bodyValues, value)
+	}
+
+	var err error
+
+	if s.Response.Status == "" {
+		s.Response.Status = StatusTemplate(strconv.Itoa(http.StatusOK))
+	}
+
+	result.status, err = parseTemplate("response.status", string(s.Response.Status))
+
+	if err != nil {
+		return err
+	}
+
+	for name, value := range s.Response.Headers {
+		result.headers[name], err = parseTemplate("response.headers."+name, value)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.Response.Body != nil && s.Response.BodyText != "" {
+		return errors.New("response.body cannot be combined with response.bodyText")
+	}
+
+	if s.Response.Body != nil {
+		var body any
+
+		if err := json.Unmarshal(s.Response.Body, &body); err != nil {
+			return fmt.Errorf("response.body: %w", err)
+		}
+
+		result.hasJSONBody = true
+		result.jsonBody, err = parseJSONTemplates("response.body", body)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.Response.BodyText != "" {
+		result.textBody, err = parseTemplate("response.bodyText", s.Response.BodyText)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	s.compiled = result
+
+	return nil
+}
+
+// match returns whether the request with its decoded JSON body matches the
+// stub, along with the path parameter values.
+func (s *Stub) match(req *http.Request, namespace string, body any) (map[string]string, bool) {
+	if s.Namespace != "" && s.Namespace != namespace {
+		return nil, false
+	}
+
+	var routeMatch mux.RouteMatch
+
+	if !s.route.Match(req, &routeMatch) {
+		return nil, false
+	}
+
+	for key, values := range s.Query {
+		if assert.ParameterQueryValues(req, key, values) != nil {
+			return nil, false
+		}
+	}
+
+	for name, values := range s.Headers {
+		if assert.HeaderValues(req, name, values) != nil {
+			return nil, false
+		}
+	}
+
+	for i, path := range s.compiled.bodyPaths {
+		value, exists := lookupJSONPath(body, path)
+		predicate := s.Body[i]
+
+		switch {
+		case predicate.Exists != nil:
+			if exists != *predicate.Exists {
+				return nil, false
+			}
+		case predicate.Matches != "":
+			text, ok := value.(string)
+
+			if !exists || !ok || !s.compiled.bodyPatterns[i].MatchString(text) {
+				return nil, false
+			}
+		default:
+			if !exists || !reflect.DeepEqual(value, s.compiled.bodyValues[i]) {
+				return nil, false
+			}
+		}
+	}
+
+	return routeMatch.Vars, true
+}
@@ -0,0 +1,241 @@
+package stub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+)
+
+// templateFuncs are the functions available to response templates.
+var templateFuncs = template.FuncMap{
+	// json returns the JSON encoding of a value, such as a request body
+	// property.
+	"json": func(v any) (string, error) {
+		result, err := json.Marshal(v)
+
+		return string(result), err
+	},
+
+	// now returns the current time in RFC 3339 format.
+	"now": func() string {
+		return time.Now().UTC().Format(time.RFC3339Nano)
+	},
+
+	// raw returns text unchanged. As the last function of a JSON body
+	// string, it inserts the text as a JSON value, such as raw "[]".
+	"raw": func(text string) string {
+		return text
+	},
+}
+
+// rawJSONFuncs are the functions which, as the last function of the only
+// action of a JSON body string, insert their output as a JSON value instead
+// of a string.
+var rawJSONFuncs = []string{"json", "raw"}
+
+// rawJSONTemplate is a JSON body string template whose output is inserted as
+// a JSON value.
+type rawJSONTemplate struct {
+	*template.Template
+}
+
+// templateData is the data of response templates.
+type templateData struct {
+	// Request the response is rendered for.
+	Request templateRequest
+
+	// Number of requests the stub responded to, including this one.
+	Sequence int
+
+	// Named counters shared by all stubs.
+	counters map[string]int
+}
+
+// Counter increments the named counter shared by all stubs and returns its
+// new value, starting at 1.
+func (d *templateData) Counter(name string) int {
+	d.counters[name]++
+
+	return d.counters[name]
+}
+
+// templateRequest is the request data of response templates.
+type templateRequest struct {
+	Method     string
+	Path       string
+	PathParams map[string]string
+	Query      url.Values
+	Headers    http.Header
+	Namespace  string
+
+	// Decoded JSON body, or nil if the body is not JSON.
+	Body any
+
+	// Raw body.
+	BodyText string
+}
+
+// JSONPath returns the value at a JSON path of the request body, such as
+// $.to.subscriberId, or nil if it does not exist.
+func (r templateRequest) JSONPath(path string) (any, error) {
+	segments, err := parseJSONPath(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result, _ := lookupJSONPath(r.Body, segments)
+
+	return result, nil
+}
+
+// parseTemplate parses a response template.
+func parseTemplate(name string, text string) (*template.Template, error) {
+	result, err := template.New(name).Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return result, nil
+}
+
+// parseJSONTemplates returns a copy of a decoded JSON value with every string
+// replaced by its parsed template, or a rawJSONTemplate if the string is a
+// single action ending in one of rawJSONFuncs, such as {{json .Request.Body}}.
+func parseJSONTemplates(path string, value any) (any, error) {
+	switch typedValue := value.(type) {
+	case string:
+		tmpl, err := parseTemplate(path, typedValue)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if isRawJSONTemplate(tmpl) {
+			return rawJSONTemplate{Template: tmpl}, nil
+		}
+
+		return tmpl, nil
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			parsed, err := parseJSONTemplates(path+"."+key, item)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = parsed
+		}
+
+		return result, nil
+	case []any:
+		result := make([]any, len(typedValue))
+
+		for i, item := range typedValue {
+			parsed, err := parseJSONTemplates(fmt.Sprintf("%s[%d]", path, i), item)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = parsed
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
+
+// isRawJSONTemplate returns whether the template is a single action whose last
+// command calls one of rawJSONFuncs.
+func isRawJSONTemplate(tmpl *template.Template) bool {
+	if tmpl.Tree == nil || len(tmpl.Tree.Root.Nodes) != 1 {
+		return false
+	}
+
+	action, ok := tmpl.Tree.Root.Nodes[0].(*parse.ActionNode)
+
+	if !ok || len(action.Pipe.Decl) > 0 || len(action.Pipe.Cmds) == 0 {
+		return false
+	}
+
+	command := action.Pipe.Cmds[len(action.Pipe.Cmds)-1]
+	identifier, ok := command.Args[0].(*parse.IdentifierNode)
+
+	return ok && slices.Contains(rawJSONFuncs, identifier.Ident)
+}
+
+// renderTemplate executes a response template.
+func renderTemplate(tmpl *template.Template, data *templateData) (string, error) {
+	var builder strings.Builder
+
+	if err := tmpl.Execute(&builder, data); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+// renderJSONTemplates returns a copy of a value returned by
+// parseJSONTemplates with every template replaced by its rendered string, and
+// every rawJSONTemplate by its decoded output.
+func renderJSONTemplates(value any, data *templateData) (any, error) {
+	switch typedValue := value.(type) {
+	case *template.Template:
+		return renderTemplate(typedValue, data)
+	case rawJSONTemplate:
+		rendered, err := renderTemplate(typedValue.Template, data)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var result any
+
+		if err := json.Unmarshal([]byte(rendered), &result); err != nil {
+			return nil, fmt.Errorf("template %s output is not valid JSON: %w", typedValue.Name(), err)
+		}
+
+		return result, nil
+	case map[string]any:
+		result := make(map[string]any, len(typedValue))
+
+		for key, item := range typedValue {
+			rendered, err := renderJSONTemplates(item, data)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result[key] = rendered
+		}
+
+		return result, nil
+	case []any:
+		result := make([]any, len(typedValue))
+
+		for i, item := range typedValue {
+			rendered, err := renderJSONTemplates(item, data)
+
+			if err != nil {
+				return nil, err
+			}
+
+			result[i] = rendered
+		}
+
+		return result, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,70 @@
+package stub
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestRenderJSONTemplates(t *testing.T) {
+	var body any
+
+	err := json.Unmarshal([]byte(`{
+		"id": "{{.Request.PathParams.id}}",
+		"sequence": "{{.Sequence | json}}",
+		"request": "{{json .Request.Body}}",
+		"empty": "{{raw \"[]\"}}",
+		"label": "call {{json .Sequence}}",
+		"count": 1
+	}`), &body)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	parsed, err := parseJSONTemplates("response.body", body)
+
+	if err != nil {
+		t.Fatalf("unexpected error parsing: %s", err)
+	}
+
+	data := &templateData{
+		Request: templateRequest{
+			Body:       map[string]any{"name": "a", "tags": []any{"b"}},
+			PathParams: map[string]string{"id": "123"},
+		},
+		Sequence: 2,
+		counters: map[string]int{},
+	}
+
+	got, err := renderJSONTemplates(parsed, data)
+
+	if err != nil {
+		t.Fatalf("unexpected error rendering: %s", err)
+	}
+
+	expected := map[string]any{
+		"id":       "123",
+		"sequence": float64(2),
+		"request":  map[string]any{"name": "a", "tags": []any{"b"}},
+		"empty":    []any{},
+		"label":    "call 2",
+		"count":    float64(1),
+	}
+
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %#v, got: %#v", expected, got)
+	}
+}
+
+func TestRenderJSONTemplatesInvalidRaw(t *testing.T) {
+	parsed, err := parseJSONTemplates("response.body", `{{raw "not json"}}`)
+
+	if err != nil {
+		t.Fatalf("unexpected error parsing: %s", err)
+	}
+
+	if _, err := renderJSONTemplates(parsed, &templateData{}); err == nil {
+		t.Error("expected error rendering invalid JSON")
+	}
+}
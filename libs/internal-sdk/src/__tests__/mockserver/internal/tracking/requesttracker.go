@@ -1,5 +1,3 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package tracking
 
 import (
@@ -9,6 +7,12 @@ import (
 	cache "github.com/go-pkgz/expirable-cache/v3"
 )
 
+const (
+	// TestInstanceIDHeader is the request header which namespaces requests
+	// per test instance.
+	TestInstanceIDHeader = "x-speakeasy-test-instance-id"
+)
+
 type RequestTracker struct {
 	cache cache.Cache[string, *testEntry]
 }
@@ -45,3 +49,8 @@ func (t *RequestTracker) GetRequestCount(testName, instanceID string) int {
 
 	return count
 }
+
+// Reset removes all tracked request counts.
+func (t *RequestTracker) Reset() {
+	t.cache.Purge()
+}
@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Default delay before the first retry of a failed delivery.
+	DefaultBackoff = 100 * time.Millisecond
+
+	// Default maximum number of delivery attempts per event.
+	DefaultMaxAttempts = 3
+
+	// SignatureHeader is the request header with the event signature, in the
+	// form t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>">.
+	SignatureHeader = "novu-signature"
+)
+
+// Event is a notification lifecycle event, such as a sent message.
+type Event struct {
+	// Unique identifier of the event.
+	ID string `json:"id"`
+
+	// Event type, such as message.sent or workflow.completed.
+	Type string `json:"type"`
+
+	// Time the event occurred.
+	Timestamp string `json:"timestamp"`
+
+	// Event details, such as the message or workflow run.
+	Data map[string]any `json:"data"`
+}
+
+// Dispatcher posts events to a webhook URL, retrying failed deliveries with
+// exponential backoff. It is safe for concurrent use.
+type Dispatcher struct {
+	backoff     time.Duration
+	client      *http.Client
+	logger      *slog.Logger
+	maxAttempts int
+	pending     sync.WaitGroup
+	secret      string
+	url         string
+}
+
+// NewDispatcher returns a dispatcher posting events to the URL.
+func NewDispatcher(url string, opts ...DispatcherOption) *Dispatcher {
+	result := &Dispatcher{
+		backoff:     DefaultBackoff,
+		client:      http.DefaultClient,
+		logger:      slog.Default(),
+		maxAttempts: DefaultMaxAttempts,
+		url:         url,
+	}
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+// Dispatch delivers the events in order in the background, logging events
+// which could not be delivered. Wait blocks until they are delivered.
+func (d *Dispatcher) Dispatch(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	d.pending.Add(1)
+
+	go func() {
+		defer d.pending.Done()
+
+		for _, event := range events {
+			err := d.Send(context.Background(), event)
+
+			if err != nil {
+				d.logger.Error("webhook delivery failed", slog.String("webhook.event.type", event.Type), slog.String("error", err.Error()))
+			}
+		}
+	}()
+}
+
+// Send delivers the event, retrying network errors, 429 Too Many Requests and
+// 5xx responses until the maximum number of attempts is reached. Other
+// responses outside the 2xx range fail without retrying.
+func (d *Dispatcher) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+
+	if err != nil {
+		return fmt.Errorf("error encoding webhook event: %w", err)
+	}
+
+	backoff := d.backoff
+
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(ctx, body)
+
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= d.maxAttempts {
+			return fmt.Errorf("error delivering webhook event %s after %d attempts: %w", event.Type, attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// Wait blocks until all events passed to Dispatch are delivered or failed.
+func (d *Dispatcher) Wait() {
+	d.pending.Wait()
+}
+
+// post sends a single delivery attempt, returning whether a failure may be
+// retried.
+func (d *Dispatcher) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(body))
+
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if d.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.secret, time.Now().Unix(), body))
+	}
+
+	res, err := d.client.Do(req)
+
+	if err != nil {
+		return true, err
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return false, nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	default:
+		return false, fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+}
+
+// Sign returns the signature header value of the body sent at the Unix
+// timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	t := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// DispatcherOption is a function which modifies the Dispatcher.
+type DispatcherOption func(*Dispatcher)
+
+// WithBackoff sets the delay before the first retry of a failed delivery,
+// which doubles with each further retry. By default, the delay is 100
+// milliseconds.
+func WithBackoff(backoff time.Duration) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.backoff = backoff
+	}
+}
+
+// WithClient sets the HTTP client events are posted with. By default, the
+// client is [http.DefaultClient].
+func WithClient(client *http.Client) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.client = client
+	}
+}
+
+// WithLogger sets the logger of failed asynchronous deliveries. By default,
+// the logger is [slog.Default].
+func WithLogger(logger *slog.Logger) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.logger = logger
+	}
+}
+
+// WithMaxAttempts sets the maximum number of delivery attempts per event. By
+// default, events are delivered in up to 3 attempts.
+func WithMaxAttempts(maxAttempts int) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+	}
+}
+
+// WithSecret sets the secret events are signed with. By default, events are
+// not signed.
+func WithSecret(secret string) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.secret = secret
+	}
+}
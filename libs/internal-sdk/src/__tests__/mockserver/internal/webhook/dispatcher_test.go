@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// receiver records posted events, answering each attempt with the next of
+// its status codes and 200 OK once they are used up.
+type receiver struct {
+	mutex    sync.Mutex
+	attempts int
+	events   []Event
+	statuses []int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	body, _ := io.ReadAll(req.Body)
+
+	signature := req.Header.Get(SignatureHeader)
+	timestamp, _, _ := strings.Cut(strings.TrimPrefix(signature, "t="), ",")
+	unix, _ := strconv.ParseInt(timestamp, 10, 64)
+
+	if signature != Sign("secret", unix, body) {
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	status := http.StatusOK
+
+	if r.attempts < len(r.statuses) {
+		status = r.statuses[r.attempts]
+	}
+
+	r.attempts++
+
+	if status == http.StatusOK {
+		var event Event
+
+		_ = json.Unmarshal(body, &event)
+		r.events = append(r.events, event)
+	}
+
+	w.WriteHeader(status)
+}
+
+func TestDispatcherSend(t *testing.T) {
+	for _, test := range []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantAttempts int
+	}{
+		{name: "delivered", wantAttempts: 1},
+		{name: "retried", statuses: []int{http.StatusInternalServerError, http.StatusTooManyRequests}, wantAttempts: 3},
+		{name: "retries exhausted", statuses: []int{500, 502, 503}, wantErr: true, wantAttempts: 3},
+		{name: "permanent failure", statuses: []int{http.StatusBadRequest}, wantErr: true, wantAttempts: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			r := &receiver{statuses: test.statuses}
+			ts := httptest.NewServer(r)
+
+			t.Cleanup(ts.Close)
+
+			d := NewDispatcher(ts.URL, WithSecret("secret"), WithBackoff(time.Millisecond))
+
+			err := d.Send(context.Background(), Event{ID: "1", Type: "message.sent", Data: map[string]any{"transactionId": "tx"}})
+
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error %t, got: %v", test.wantErr, err)
+			}
+
+			if r.attempts != test.wantAttempts {
+				t.Errorf("expected %d attempts, got: %d", test.wantAttempts, r.attempts)
+			}
+
+			if !test.wantErr && (len(r.events) != 1 || r.events[0].Data["transactionId"] != "tx") {
+				t.Errorf("expected signed event to be received, got: %+v", r.events)
+			}
+		})
+	}
+}
+
+func TestDispatcherDispatch(t *testing.T) {
+	r := &receiver{}
+	ts := httptest.NewServer(r)
+
+	t.Cleanup(ts.Close)
+
+	d := NewDispatcher(ts.URL, WithSecret("secret"))
+
+	d.Dispatch(Event{ID: "1", Type: "message.sent"}, Event{ID: "2", Type: "workflow.completed"})
+	d.Wait()
+
+	if len(r.events) != 2 || r.events[0].Type != "message.sent" || r.events[1].Type != "workflow.completed" {
+		t.Errorf("expected events in order, got: %+v", r.events)
+	}
+
+	d = NewDispatcher(ts.URL, WithSecret("wrong"), WithMaxAttempts(1))
+
+	if err := d.Send(context.Background(), Event{ID: "3", Type: "message.sent"}); err == nil {
+		t.Errorf("expected error for invalid signature")
+	}
+}
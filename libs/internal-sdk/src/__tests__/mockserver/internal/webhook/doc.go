@@ -0,0 +1,3 @@
+// Package webhook contains the dispatcher of outbound webhook events, which
+// posts signed notification lifecycle events to a local URL.
+package webhook
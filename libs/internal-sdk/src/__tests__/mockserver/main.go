@@ -1,5 +1,3 @@
-// Code generated by Speakeasy (https://speakeasy.com). DO NOT EDIT.
-
 package main
 
 import (
@@ -18,8 +16,18 @@ func main() {
 	ctx := context.Background()
 
 	address := flag.String("address", server.DefaultAddress, fmt.Sprintf("server listen address (default: %s)", server.DefaultAddress))
+	coverageFile := flag.String("coverage-file", "", fmt.Sprintf("file the operation coverage report is written to on shutdown (default: %s in the HTTP log directory)", logging.DefaultCoverageFilename))
 	logFormat := flag.String("log-format", logging.DefaultFormat, fmt.Sprintf("logging format (default: %s, supported: %s)", logging.DefaultFormat, strings.Join(logging.Formats(), ", ")))
 	logLevel := flag.String("log-level", logging.DefaultLevel, fmt.Sprintf("logging level (default: %s, supported: %s)", logging.DefaultLevel, strings.Join(logging.Levels(), ", ")))
+	httpLogDir := flag.String("http-log-dir", logging.DefaultHTTPFileDirectory, fmt.Sprintf("directory for HTTP request and response logs (default: %s)", logging.DefaultHTTPFileDirectory))
+	httpLogGzip := flag.Bool("http-log-gzip", false, "gzip compress HTTP request and response logs")
+	httpLogKeep := flag.Bool("http-log-keep", false, "keep HTTP request and response logs of the previous run")
+	httpLogMaxCalls := flag.Int64("http-log-max-calls", 0, "maximum HTTP logged calls kept per operation, oldest removed first (default: 0, unlimited)")
+	httpLogMaxSize := flag.Int64("http-log-max-size", 0, "maximum total size in bytes of HTTP logs, oldest removed first (default: 0, unlimited)")
+	seed := flag.String("seed", "", "seed data file (JSON or YAML) loaded at startup")
+	webhookSecret := flag.String("webhook-secret", "", "secret outbound webhook events are signed with")
+	webhookURL := flag.String("webhook-url", "", "URL outbound webhook events are posted to (default: none)")
+	streamMaxBodySize := flag.Int("stream-max-body-size", logging.DefaultHTTPStreamMaxBodySize, fmt.Sprintf("size in bytes at which streamed bodies are truncated, 0 disables truncation (default: %d)", logging.DefaultHTTPStreamMaxBodySize))
 
 	flag.Parse()
 
@@ -32,7 +40,20 @@ func main() {
 
 	serverOpts := []server.ServerOption{
 		server.WithAddress(*address),
+		server.WithCoverageFile(*coverageFile),
+		server.WithHTTPFileDirectory(*httpLogDir),
+		server.WithHTTPFileGzip(*httpLogGzip),
+		server.WithHTTPFileKeepPrevious(*httpLogKeep),
+		server.WithHTTPFileMaxCalls(*httpLogMaxCalls),
+		server.WithHTTPFileMaxSize(*httpLogMaxSize),
 		server.WithLogger(logger),
+		server.WithStreamMaxBodySize(*streamMaxBodySize),
+		server.WithWebhookSecret(*webhookSecret),
+		server.WithWebhookURL(*webhookURL),
+	}
+
+	if *seed != "" {
+		serverOpts = append(serverOpts, server.WithSeed(*seed))
 	}
 
 	s, err := server.NewServer(ctx, serverOpts...)
@@ -0,0 +1,117 @@
+package testharness
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// Mock server internal route prefix.
+	adminPathPrefix = "/_mockserver"
+)
+
+// Client is a typed client for the mock server /_mockserver admin APIs.
+type Client struct {
+	// Mock server base URL, such as http://localhost:18080.
+	baseURL string
+
+	// Underlying HTTP client.
+	httpClient *http.Client
+}
+
+// NewClient creates a Client for the mock server at baseURL. If httpClient is
+// nil, [http.DefaultClient] is used.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: httpClient,
+	}
+}
+
+// HAR returns all operation logs as a HAR 1.2 document.
+func (c *Client) HAR(ctx context.Context) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/log.har", nil, http.StatusOK)
+}
+
+// Health verifies the mock server is running.
+func (c *Client) Health(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodGet, "/health", nil, http.StatusOK)
+
+	return err
+}
+
+// OperationHAR returns the logs of a single operation as a HAR 1.2 document.
+func (c *Client) OperationHAR(ctx context.Context, operationId string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/log/"+url.PathEscape(operationId)+".har", nil, http.StatusOK)
+}
+
+// ResetState clears the mock server state, operation logs and request
+// tracking counts.
+func (c *Client) ResetState(ctx context.Context) error {
+	_, err := c.do(ctx, http.MethodPost, "/state/reset", nil, http.StatusNoContent)
+
+	return err
+}
+
+// RestoreState replaces the mock server state with a snapshot previously
+// returned by State.
+func (c *Client) RestoreState(ctx context.Context, snapshot []byte) error {
+	_, err := c.do(ctx, http.MethodPut, "/state", snapshot, http.StatusNoContent)
+
+	return err
+}
+
+// State returns the mock server state as a versioned JSON snapshot.
+func (c *Client) State(ctx context.Context) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, "/state", nil, http.StatusOK)
+}
+
+// do sends a request to the admin API at path and returns the response body.
+// Any response status other than expectedStatus is returned as an error which
+// includes the response body.
+func (c *Client) do(ctx context.Context, method string, path string, body []byte, expectedStatus int) ([]byte, error) {
+	var reqBody io.Reader
+
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+adminPathPrefix+path, reqBody)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s %s request: %w", method, path, err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("error sending %s %s request: %w", method, path, err)
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s %s response: %w", method, path, err)
+	}
+
+	if resp.StatusCode != expectedStatus {
+		return nil, fmt.Errorf("unexpected %s %s response status %s: %s", method, path, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	return respBody, nil
+}
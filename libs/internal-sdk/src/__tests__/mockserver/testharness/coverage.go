@@ -0,0 +1,33 @@
+package testharness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"mockserver/internal/coverage"
+)
+
+// CoverageReport is the coverage of all API operations by logged calls.
+type CoverageReport = coverage.Report
+
+// Coverage returns which operations, documented response status codes and
+// union variants were exercised.
+func (c *Client) Coverage(ctx context.Context) (*CoverageReport, error) {
+	body, err := c.do(ctx, http.MethodGet, "/coverage", nil, http.StatusOK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result CoverageReport
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding coverage response: %w", err)
+	}
+
+	return &result, nil
+}
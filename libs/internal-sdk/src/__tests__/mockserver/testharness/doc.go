@@ -0,0 +1,3 @@
+// Package testharness runs an isolated mock server for each Go test, with a
+// typed client for the /_mockserver admin APIs.
+package testharness
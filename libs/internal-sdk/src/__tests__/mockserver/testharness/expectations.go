@@ -0,0 +1,111 @@
+package testharness
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"mockserver/internal/expectation"
+)
+
+// Expectation describes calls a test expects the mock server to receive.
+type Expectation = expectation.Expectation
+
+// ExpectationTimes is the number of calls an Expectation requires.
+type ExpectationTimes = expectation.Times
+
+// VerifyReport is the result of verifying expectations.
+type VerifyReport = expectation.Report
+
+// AddExpectation registers e and returns it with its generated ID.
+func (c *Client) AddExpectation(ctx context.Context, e Expectation) (*Expectation, error) {
+	reqBody, err := json.Marshal(e)
+
+	if err != nil {
+		return nil, fmt.Errorf("error encoding expectation: %w", err)
+	}
+
+	body, err := c.do(ctx, http.MethodPost, "/expectations", reqBody, http.StatusCreated)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result Expectation
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding expectation response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ClearExpectations removes the expectations and recorded calls of namespace,
+// or all of them if namespace is empty.
+func (c *Client) ClearExpectations(ctx context.Context, namespace string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/expectations?"+namespaceQuery(namespace), nil, http.StatusNoContent)
+
+	return err
+}
+
+// Expectations returns the expectations of namespace, or all of them if
+// namespace is empty, with their current counts.
+func (c *Client) Expectations(ctx context.Context, namespace string) ([]*Expectation, error) {
+	body, err := c.do(ctx, http.MethodGet, "/expectations?"+namespaceQuery(namespace), nil, http.StatusOK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Expectations []*Expectation `json:"expectations"`
+	}
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding expectations response: %w", err)
+	}
+
+	return result.Expectations, nil
+}
+
+// Verify checks the expectations and calls of namespace, or all of them if
+// namespace is empty. If any expectation is unmet or any unexpected call was
+// received, the report is returned along with an error containing its diff.
+func (c *Client) Verify(ctx context.Context, namespace string) (*VerifyReport, error) {
+	body, err := c.do(ctx, http.MethodGet, "/expectations/verify?"+namespaceQuery(namespace), nil, http.StatusOK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result VerifyReport
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding verify response: %w", err)
+	}
+
+	if !result.Satisfied {
+		return &result, errors.New(result.Diff)
+	}
+
+	return &result, nil
+}
+
+// namespaceQuery returns the encoded namespace query parameter, or an empty
+// string if namespace is empty.
+func namespaceQuery(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+
+	return url.Values{"namespace": {namespace}}.Encode()
+}
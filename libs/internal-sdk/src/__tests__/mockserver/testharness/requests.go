@@ -0,0 +1,87 @@
+package testharness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"mockserver/internal/logging"
+)
+
+// Request is a single logged operation call returned by the
+// /_mockserver/requests endpoint.
+type Request = logging.JournalEntry
+
+// RequestsFilter selects which logged operation calls are returned. Empty
+// fields match all calls.
+type RequestsFilter struct {
+	// OAS operation identifiers.
+	OperationIDs []string
+
+	// HTTP methods, such as POST.
+	Methods []string
+
+	// Request path prefix, such as /v1/events.
+	PathPrefix string
+
+	// Only calls received after this time.
+	Since time.Time
+
+	// Response status classes or codes, such as 5xx or 404.
+	Statuses []string
+
+	// Test namespace, as sent via the x-speakeasy-test-instance-id header.
+	Namespace string
+}
+
+// Requests returns the logged operation calls matching filter, ordered by
+// request time.
+func (c *Client) Requests(ctx context.Context, filter RequestsFilter) ([]Request, error) {
+	query := url.Values{}
+
+	if len(filter.OperationIDs) > 0 {
+		query.Set("operationId", strings.Join(filter.OperationIDs, ","))
+	}
+
+	if len(filter.Methods) > 0 {
+		query.Set("method", strings.Join(filter.Methods, ","))
+	}
+
+	if filter.PathPrefix != "" {
+		query.Set("pathPrefix", filter.PathPrefix)
+	}
+
+	if !filter.Since.IsZero() {
+		query.Set("since", filter.Since.Format(time.RFC3339Nano))
+	}
+
+	if len(filter.Statuses) > 0 {
+		query.Set("status", strings.Join(filter.Statuses, ","))
+	}
+
+	if filter.Namespace != "" {
+		query.Set("namespace", filter.Namespace)
+	}
+
+	body, err := c.do(ctx, http.MethodGet, "/requests?"+query.Encode(), nil, http.StatusOK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Requests []Request `json:"requests"`
+	}
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding requests response: %w", err)
+	}
+
+	return result.Requests, nil
+}
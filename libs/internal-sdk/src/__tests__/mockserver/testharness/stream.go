@@ -0,0 +1,137 @@
+package testharness
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"mockserver/internal/logging"
+)
+
+const (
+	// Maximum size in bytes of a single streamed event.
+	maxStreamEventSize = 64 << 20
+)
+
+// StreamEvent is a single request and response pair sent by the
+// /_mockserver/stream endpoint.
+type StreamEvent = logging.HTTPStreamEvent
+
+// StreamFilter selects which events are streamed. Empty fields match all
+// events.
+type StreamFilter struct {
+	// OAS operation identifiers.
+	OperationIDs []string
+
+	// HTTP methods, such as POST.
+	Methods []string
+
+	// Response status classes, such as 2xx or 5xx.
+	StatusClasses []string
+
+	// Test namespace, as sent via the x-speakeasy-test-instance-id header.
+	Namespace string
+
+	// Whether to include /_mockserver requests.
+	Internal bool
+}
+
+// Stream subscribes to live request and response pairs matching filter. The
+// subscription is active once Stream returns, so calls made afterwards are
+// always received. The channel is closed when ctx is done or the server shuts
+// down.
+func (c *Client) Stream(ctx context.Context, filter StreamFilter) (<-chan StreamEvent, error) {
+	query := url.Values{}
+
+	if len(filter.OperationIDs) > 0 {
+		query.Set("operationId", strings.Join(filter.OperationIDs, ","))
+	}
+
+	if len(filter.Methods) > 0 {
+		query.Set("method", strings.Join(filter.Methods, ","))
+	}
+
+	if len(filter.StatusClasses) > 0 {
+		query.Set("status", strings.Join(filter.StatusClasses, ","))
+	}
+
+	if filter.Namespace != "" {
+		query.Set("namespace", filter.Namespace)
+	}
+
+	if filter.Internal {
+		query.Set("internal", "true")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+adminPathPrefix+"/stream?"+query.Encode(), nil)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating stream request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("error sending stream request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		body, _ := io.ReadAll(resp.Body)
+
+		return nil, fmt.Errorf("unexpected stream response status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	events := make(chan StreamEvent)
+
+	go readStreamEvents(ctx, resp.Body, events)
+
+	return events, nil
+}
+
+// readStreamEvents decodes Server-Sent Events from body into events until the
+// body ends or ctx is done, then closes events.
+func readStreamEvents(ctx context.Context, body io.ReadCloser, events chan<- StreamEvent) {
+	defer close(events)
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, maxStreamEventSize)
+
+	var eventName string
+	var data strings.Builder
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if eventName == "call" && data.Len() > 0 {
+				var event StreamEvent
+
+				if err := json.Unmarshal([]byte(data.String()), &event); err == nil {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			eventName = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event: "):
+			eventName = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data.WriteString(strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
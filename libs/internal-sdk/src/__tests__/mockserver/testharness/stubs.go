@@ -0,0 +1,88 @@
+package testharness
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"mockserver/internal/stub"
+)
+
+// Stub is a response returned instead of the generated handler response for
+// matching requests.
+type Stub = stub.Stub
+
+// StubBodyPredicate is a condition on the value at a JSON path of the request
+// body.
+type StubBodyPredicate = stub.BodyPredicate
+
+// StubResponse is a templated Stub response.
+type StubResponse = stub.Response
+
+// StubStatus is a response status code, either as a number or as a template
+// rendering one.
+type StubStatus = stub.StatusTemplate
+
+// AddStub registers s and returns it with its generated ID.
+func (c *Client) AddStub(ctx context.Context, s Stub) (*Stub, error) {
+	reqBody, err := json.Marshal(s)
+
+	if err != nil {
+		return nil, fmt.Errorf("error encoding stub: %w", err)
+	}
+
+	body, err := c.do(ctx, http.MethodPost, "/stubs", reqBody, http.StatusCreated)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result Stub
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding stub response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ClearStubs removes the stubs of namespace, or all stubs and their sequence
+// counters if namespace is empty.
+func (c *Client) ClearStubs(ctx context.Context, namespace string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/stubs?"+namespaceQuery(namespace), nil, http.StatusNoContent)
+
+	return err
+}
+
+// RemoveStub removes a single stub.
+func (c *Client) RemoveStub(ctx context.Context, id string) error {
+	_, err := c.do(ctx, http.MethodDelete, "/stubs/"+url.PathEscape(id), nil, http.StatusNoContent)
+
+	return err
+}
+
+// Stubs returns the stubs of namespace, or all of them if namespace is empty,
+// in matching order.
+func (c *Client) Stubs(ctx context.Context, namespace string) ([]*Stub, error) {
+	body, err := c.do(ctx, http.MethodGet, "/stubs?"+namespaceQuery(namespace), nil, http.StatusOK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Stubs []*Stub `json:"stubs"`
+	}
+
+	err = json.Unmarshal(body, &result)
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding stubs response: %w", err)
+	}
+
+	return result.Stubs, nil
+}
@@ -0,0 +1,146 @@
+package testharness
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"mockserver/internal/server"
+)
+
+// Harness is a mock server running for the duration of a single test.
+type Harness struct {
+	// Client for the /_mockserver admin APIs.
+	Client *Client
+
+	// Underlying HTTP test server.
+	httpServer *httptest.Server
+
+	// Directory for raw HTTP request and response files.
+	logDir string
+}
+
+// Option is a function which modifies the Harness configuration.
+type Option func(*config)
+
+// config contains the Harness configuration set via Option.
+type config struct {
+	// Path to the operation coverage report file written on shutdown.
+	coverageFilePath string
+
+	// Logger implementation.
+	logger *slog.Logger
+
+	// Path to a seed file loaded before the server starts.
+	seedPath string
+
+	// Secret outbound webhook events are signed with.
+	webhookSecret string
+
+	// URL outbound webhook events are posted to.
+	webhookURL string
+}
+
+// WithCoverageFile writes the operation coverage report to the file at path
+// when the mock server shuts down. By default, the report is written into the
+// temporary HTTP log directory, which is removed after the test.
+func WithCoverageFile(path string) Option {
+	return func(c *config) {
+		c.coverageFilePath = path
+	}
+}
+
+// WithLogger sets the logger implementation for the mock server. By default,
+// server logs are discarded.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithSeed loads the seed file at path before the mock server starts. By
+// default, the server state is empty.
+func WithSeed(path string) Option {
+	return func(c *config) {
+		c.seedPath = path
+	}
+}
+
+// WithWebhook posts outbound webhook events of the mock server, such as sent
+// messages of triggers, to url, signed with secret. By default, no events are
+// posted.
+func WithWebhook(url string, secret string) Option {
+	return func(c *config) {
+		c.webhookSecret = secret
+		c.webhookURL = url
+	}
+}
+
+// Start creates and starts a mock server on an ephemeral port, writing HTTP
+// logs into a temporary directory. The server is shut down when the test and
+// all its subtests complete.
+func Start(t testing.TB, opts ...Option) *Harness {
+	t.Helper()
+
+	ctx := context.Background()
+
+	cfg := &config{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	logDir := t.TempDir()
+
+	serverOpts := []server.ServerOption{
+		server.WithCoverageFile(cfg.coverageFilePath),
+		server.WithHTTPFileDirectory(logDir),
+		server.WithLogger(cfg.logger),
+		server.WithWebhookSecret(cfg.webhookSecret),
+		server.WithWebhookURL(cfg.webhookURL),
+	}
+
+	if cfg.seedPath != "" {
+		serverOpts = append(serverOpts, server.WithSeed(cfg.seedPath))
+	}
+
+	s, err := server.NewServer(ctx, serverOpts...)
+
+	if err != nil {
+		t.Fatalf("error creating mock server: %s", err)
+	}
+
+	httpServer := httptest.NewServer(s.Handler())
+
+	t.Cleanup(func() {
+		// Shut down first, which ends open streams that would otherwise block
+		// closing the HTTP test server.
+		err := s.Shutdown(ctx)
+
+		if err != nil {
+			t.Errorf("error shutting down mock server: %s", err)
+		}
+
+		httpServer.Close()
+	})
+
+	return &Harness{
+		Client:     NewClient(httpServer.URL, httpServer.Client()),
+		httpServer: httpServer,
+		logDir:     logDir,
+	}
+}
+
+// LogDir returns the directory containing raw HTTP request and response files.
+func (h *Harness) LogDir() string {
+	return h.logDir
+}
+
+// URL returns the mock server base URL, such as http://127.0.0.1:54321.
+func (h *Harness) URL() string {
+	return h.httpServer.URL
+}
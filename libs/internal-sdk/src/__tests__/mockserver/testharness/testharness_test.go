@@ -0,0 +1,272 @@
+package testharness
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	// Seed file shared with the server tests.
+	testSeedPath = "../internal/server/testdata/seed.yaml"
+)
+
+func TestStartShutsDownOnCleanup(t *testing.T) {
+	ctx := context.Background()
+	coverageFilePath := filepath.Join(t.TempDir(), "coverage.json")
+
+	var (
+		client *Client
+		logDir string
+	)
+
+	t.Run("start", func(t *testing.T) {
+		h := Start(t, WithSeed(testSeedPath), WithCoverageFile(coverageFilePath))
+		client = h.Client
+		logDir = h.LogDir()
+
+		if info, err := os.Stat(logDir); err != nil || !info.IsDir() {
+			t.Fatalf("expected log directory %s, got: %v", logDir, err)
+		}
+
+		if err := client.Health(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		state, err := client.State(ctx)
+
+		if err != nil || !strings.Contains(string(state), `"env-dev"`) {
+			t.Fatalf("expected seeded state, got: %s %v", state, err)
+		}
+	})
+
+	if err := client.Health(ctx); err == nil {
+		t.Errorf("expected error after cleanup, got: nil")
+	}
+
+	if _, err := os.Stat(logDir); !os.IsNotExist(err) {
+		t.Errorf("expected log directory to be removed after cleanup, got: %v", err)
+	}
+
+	if _, err := os.Stat(coverageFilePath); err != nil {
+		t.Errorf("expected coverage file written on shutdown, got: %v", err)
+	}
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	h := Start(t, WithSeed(testSeedPath))
+
+	stub, err := h.Client.AddStub(ctx, Stub{
+		Method: http.MethodGet,
+		Path:   "/v1/environments",
+		Response: StubResponse{
+			Status: StubStatus("418"),
+			Body:   json.RawMessage(`{"stubbed":true}`),
+		},
+	})
+
+	if err != nil || stub.ID == "" {
+		t.Fatalf("expected stub with generated ID, got: %+v %v", stub, err)
+	}
+
+	expectation, err := h.Client.AddExpectation(ctx, Expectation{
+		Method: http.MethodPost,
+		Path:   "/v1/events/trigger",
+		Times:  ExpectationTimes{Exactly: new(int)},
+	})
+
+	if err != nil || expectation.ID == "" {
+		t.Fatalf("expected expectation with generated ID, got: %+v %v", expectation, err)
+	}
+
+	events, err := h.Client.Stream(ctx, StreamFilter{OperationIDs: []string{"EventsController_trigger"}})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if status := apiCall(t, h, http.MethodGet, "/v1/environments", ""); status != 418 {
+		t.Errorf("expected stubbed status 418, got: %d", status)
+	}
+
+	if status := apiCall(t, h, http.MethodPost, "/v1/events/trigger", `{"name":"welcome","to":"alice"}`); status != http.StatusCreated {
+		t.Fatalf("expected status 201, got: %d", status)
+	}
+
+	select {
+	case event := <-events:
+		if event.OperationID != "EventsController_trigger" || event.Response.Status != http.StatusCreated {
+			t.Errorf("expected streamed trigger call, got: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected streamed event, got: timeout")
+	}
+
+	t.Run("stubs", func(t *testing.T) {
+		stubs, err := h.Client.Stubs(ctx, "")
+
+		if err != nil || len(stubs) != 1 || stubs[0].Calls != 1 {
+			t.Fatalf("expected 1 stub with 1 call, got: %+v %v", stubs, err)
+		}
+
+		if err := h.Client.RemoveStub(ctx, stub.ID); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err := h.Client.RemoveStub(ctx, stub.ID); err == nil {
+			t.Errorf("expected error removing a removed stub, got: nil")
+		}
+
+		if _, err := h.Client.AddStub(ctx, Stub{Namespace: "other", Method: http.MethodGet, Path: "/v1/environments"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err := h.Client.ClearStubs(ctx, "other"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if stubs, err := h.Client.Stubs(ctx, ""); err != nil || len(stubs) != 0 {
+			t.Errorf("expected no stubs, got: %+v %v", stubs, err)
+		}
+	})
+
+	t.Run("expectations", func(t *testing.T) {
+		expectations, err := h.Client.Expectations(ctx, "")
+
+		if err != nil || len(expectations) != 1 || expectations[0].Count != 1 {
+			t.Fatalf("expected 1 expectation with count 1, got: %+v %v", expectations, err)
+		}
+
+		report, err := h.Client.Verify(ctx, "")
+
+		if err == nil || report == nil || report.Satisfied || !strings.Contains(err.Error(), expectation.ID) {
+			t.Errorf("expected unmet expectation %s, got: %+v %v", expectation.ID, report, err)
+		}
+
+		if err := h.Client.ClearExpectations(ctx, ""); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if expectations, err := h.Client.Expectations(ctx, ""); err != nil || len(expectations) != 0 {
+			t.Errorf("expected no expectations, got: %+v %v", expectations, err)
+		}
+	})
+
+	t.Run("requests", func(t *testing.T) {
+		requests, err := h.Client.Requests(ctx, RequestsFilter{
+			Methods:    []string{http.MethodPost},
+			PathPrefix: "/v1/events",
+			Statuses:   []string{"2xx"},
+		})
+
+		if err != nil || len(requests) != 1 || requests[0].OperationID != "EventsController_trigger" {
+			t.Fatalf("expected 1 trigger call, got: %+v %v", requests, err)
+		}
+
+		requests, err = h.Client.Requests(ctx, RequestsFilter{Since: time.Now().Add(time.Hour)})
+
+		if err != nil || len(requests) != 0 {
+			t.Errorf("expected no future calls, got: %+v %v", requests, err)
+		}
+	})
+
+	t.Run("coverage", func(t *testing.T) {
+		report, err := h.Client.Coverage(ctx)
+
+		if err != nil || report.Summary.Operations.Hit == 0 || report.Summary.Operations.Total < report.Summary.Operations.Hit {
+			t.Errorf("expected covered operations, got: %+v %v", report, err)
+		}
+	})
+
+	t.Run("har", func(t *testing.T) {
+		all, err := h.Client.HAR(ctx)
+
+		if err != nil || harEntries(t, all) != 2 {
+			t.Errorf("expected 2 HAR entries, got: %s %v", all, err)
+		}
+
+		operation, err := h.Client.OperationHAR(ctx, "EventsController_trigger")
+
+		if err != nil || harEntries(t, operation) != 1 {
+			t.Errorf("expected 1 HAR entry, got: %s %v", operation, err)
+		}
+	})
+
+	t.Run("state", func(t *testing.T) {
+		snapshot, err := h.Client.State(ctx)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if err := h.Client.ResetState(ctx); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if status := apiCall(t, h, http.MethodPost, "/v1/events/trigger", `{"name":"welcome","to":"alice"}`); status != http.StatusUnauthorized {
+			t.Errorf("expected status 401 after reset, got: %d", status)
+		}
+
+		if err := h.Client.RestoreState(ctx, snapshot); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		restored, err := h.Client.State(ctx)
+
+		if err != nil || string(restored) != string(snapshot) {
+			t.Errorf("expected restored snapshot to equal the export, got: %s %v", restored, err)
+		}
+
+		if err := h.Client.RestoreState(ctx, []byte(`{}`)); err == nil {
+			t.Errorf("expected error restoring an invalid snapshot, got: nil")
+		}
+	})
+}
+
+// apiCall sends a request authenticated with the API key of the seeded dev
+// environment and returns the response status code.
+func apiCall(t *testing.T, h *Harness, method string, path string, body string) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, h.URL()+path, strings.NewReader(body))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	req.Header.Set("Authorization", "ApiKey dev-key")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	resp.Body.Close()
+
+	return resp.StatusCode
+}
+
+// harEntries returns the number of entries of the HAR document.
+func harEntries(t *testing.T, body []byte) int {
+	t.Helper()
+
+	var har struct {
+		Log struct {
+			Entries []json.RawMessage `json:"entries"`
+		} `json:"log"`
+	}
+
+	if err := json.Unmarshal(body, &har); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return len(har.Log.Entries)
+}